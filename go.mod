@@ -1,8 +1,11 @@
 module github.com/zauberhaus/slice_utils
 
-go 1.25
+go 1.25.0
 
-require github.com/stretchr/testify v1.11.1
+require (
+	github.com/stretchr/testify v1.11.1
+	golang.org/x/sync v0.20.0
+)
 
 require (
 	github.com/davecgh/go-spew v1.1.1 // indirect
@@ -6,11 +6,22 @@
 package slice_utils
 
 import (
+	"bufio"
 	"cmp"
+	"container/heap"
+	"container/list"
+	"encoding/csv"
+	"encoding/json"
 	"fmt"
+	"io"
 	"iter"
+	"maps"
 	"regexp"
 	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
 
 	"hash/maphash"
 )
@@ -52,15 +63,7 @@ func RemoveSeq[S comparable](s iter.Seq[S], g iter.Seq[S]) iter.Seq[S] {
 func PatternSeq[S any](s iter.Seq[S], pattern *regexp.Regexp) iter.Seq[S] {
 	return func(yield func(s S) bool) {
 		for v := range s {
-			var txt string
-			switch o := any(v).(type) {
-			case string:
-				txt = o
-			case fmt.Stringer:
-				txt = o.String()
-			default:
-				txt = fmt.Sprintf("%v", o)
-			}
+			txt := stringify(v)
 
 			if pattern.MatchString(txt) {
 				if !yield(v) {
@@ -71,18 +74,21 @@ func PatternSeq[S any](s iter.Seq[S], pattern *regexp.Regexp) iter.Seq[S] {
 	}
 }
 
+func stringify(v any) string {
+	switch o := v.(type) {
+	case string:
+		return o
+	case fmt.Stringer:
+		return o.String()
+	default:
+		return fmt.Sprintf("%v", o)
+	}
+}
+
 func StringPatternSeq[S any](s iter.Seq[S], pattern string) iter.Seq[S] {
 	return func(yield func(s S) bool) {
 		for v := range s {
-			var txt string
-			switch o := any(v).(type) {
-			case string:
-				txt = o
-			case fmt.Stringer:
-				txt = o.String()
-			default:
-				txt = fmt.Sprintf("%v", o)
-			}
+			txt := stringify(v)
 
 			if txt == pattern {
 				if !yield(v) {
@@ -253,3 +259,1386 @@ func AnySeq[S any](s iter.Seq[S]) iter.Seq[any] {
 		}
 	}
 }
+
+// FilterIndexSeq works like FilterSeq but also passes the position of the
+// element in s, counted from zero for every iteration.
+func FilterIndexSeq[V any](s iter.Seq[V], fn func(i int, val V) bool) iter.Seq[V] {
+	return func(yield func(s V) bool) {
+		i := 0
+
+		for v := range s {
+			if fn(i, v) {
+				if !yield(v) {
+					return
+				}
+			}
+
+			i++
+		}
+	}
+}
+
+func RejectSeq[S any](s iter.Seq[S], fn func(S) bool) iter.Seq[S] {
+	return func(yield func(s S) bool) {
+		for v := range s {
+			if !fn(v) {
+				if !yield(v) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// LinesSeq yields the lines of r as read by a bufio.Scanner. Lines are limited
+// to bufio.MaxScanTokenSize bytes; longer lines stop the sequence with
+// bufio.ErrTooLong. A read error is yielded with an empty line as the last
+// element, io.EOF is not reported.
+func LinesSeq(r io.Reader) iter.Seq2[string, error] {
+	return func(yield func(string, error) bool) {
+		scanner := bufio.NewScanner(r)
+
+		for scanner.Scan() {
+			if !yield(scanner.Text(), nil) {
+				return
+			}
+		}
+
+		if err := scanner.Err(); err != nil {
+			yield("", err)
+		}
+	}
+}
+
+// WriteJSONArray streams s to w as a JSON array, encoding one element at a
+// time. The iteration stops at the first encode or write error.
+func WriteJSONArray[V any](w io.Writer, s iter.Seq[V]) error {
+	if _, err := io.WriteString(w, "["); err != nil {
+		return err
+	}
+
+	var err error
+	first := true
+
+	for v := range s {
+		var data []byte
+
+		data, err = json.Marshal(v)
+		if err != nil {
+			break
+		}
+
+		if !first {
+			if _, err = io.WriteString(w, ","); err != nil {
+				break
+			}
+		}
+
+		first = false
+
+		if _, err = w.Write(data); err != nil {
+			break
+		}
+	}
+
+	if err != nil {
+		return err
+	}
+
+	_, err = io.WriteString(w, "]")
+
+	return err
+}
+
+// WriteCSVSeq writes the header, if not empty, and one CSV record per element
+// of s to w. Output is buffered, so a write error on w only surfaces when the
+// buffer is flushed; the iteration stops there or the error is returned by the
+// final flush.
+func WriteCSVSeq[V any](w io.Writer, s iter.Seq[V], header []string, row func(V) []string) error {
+	cw := csv.NewWriter(w)
+
+	if len(header) > 0 {
+		if err := cw.Write(header); err != nil {
+			return err
+		}
+	}
+
+	for v := range s {
+		if err := cw.Write(row(v)); err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+
+	return cw.Error()
+}
+
+// ClampSeq limits every element of s to the range [lo, hi]. The bounds are
+// swapped if lo is greater than hi.
+func ClampSeq[V cmp.Ordered](s iter.Seq[V], lo V, hi V) iter.Seq[V] {
+	if lo > hi {
+		lo, hi = hi, lo
+	}
+
+	return ReplaceFuncSeq(s, func(v V) V {
+		return min(max(v, lo), hi)
+	})
+}
+
+func RunningMaxSeq[V cmp.Ordered](s iter.Seq[V]) iter.Seq[V] {
+	return runningSeq(s, func(a, b V) V { return max(a, b) })
+}
+
+func RunningMinSeq[V cmp.Ordered](s iter.Seq[V]) iter.Seq[V] {
+	return runningSeq(s, func(a, b V) V { return min(a, b) })
+}
+
+func runningSeq[V cmp.Ordered](s iter.Seq[V], fn func(V, V) V) iter.Seq[V] {
+	return func(yield func(s V) bool) {
+		var current V
+		first := true
+
+		for v := range s {
+			if first {
+				current = v
+				first = false
+			} else {
+				current = fn(current, v)
+			}
+
+			if !yield(current) {
+				return
+			}
+		}
+	}
+}
+
+// SortSeq yields the elements of s in ascending order. The whole sequence is
+// buffered in memory before the first element is yielded.
+func SortSeq[V cmp.Ordered](s iter.Seq[V]) iter.Seq[V] {
+	return func(yield func(s V) bool) {
+		items := slices.Collect(s)
+		slices.Sort(items)
+
+		for _, v := range items {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// SortFuncSeq yields the elements of s in the stable order defined by less.
+// The whole sequence is buffered in memory before the first element is
+// yielded.
+func SortFuncSeq[V any](s iter.Seq[V], less func(a, b V) bool) iter.Seq[V] {
+	return func(yield func(s V) bool) {
+		items := slices.Collect(s)
+		sort.SliceStable(items, func(i, j int) bool {
+			return less(items[i], items[j])
+		})
+
+		for _, v := range items {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+func KWayMergeSeq[V cmp.Ordered](seqs ...iter.Seq[V]) iter.Seq[V] {
+	return KWayMergeFuncSeq(cmp.Compare[V], seqs...)
+}
+
+// KWayMergeFuncSeq merges individually sorted sequences into one sequence
+// sorted by f. Only the current head of every input is held in memory; equal
+// elements are yielded in the order of their input sequences.
+func KWayMergeFuncSeq[V any](f func(a, b V) int, seqs ...iter.Seq[V]) iter.Seq[V] {
+	return func(yield func(s V) bool) {
+		h := &mergeHeap[V]{cmp: f}
+
+		for i, s := range seqs {
+			next, stop := iter.Pull(s)
+			defer stop()
+
+			if v, ok := next(); ok {
+				heap.Push(h, mergeItem[V]{value: v, index: i, next: next})
+			}
+		}
+
+		for h.Len() > 0 {
+			if !yield(h.items[0].value) {
+				return
+			}
+
+			if v, ok := h.items[0].next(); ok {
+				h.items[0].value = v
+				heap.Fix(h, 0)
+			} else {
+				heap.Pop(h)
+			}
+		}
+	}
+}
+
+type mergeItem[V any] struct {
+	value V
+	index int
+	next  func() (V, bool)
+}
+
+type mergeHeap[V any] struct {
+	items []mergeItem[V]
+	cmp   func(a, b V) int
+}
+
+func (h *mergeHeap[V]) Len() int {
+	return len(h.items)
+}
+
+func (h *mergeHeap[V]) Less(i, j int) bool {
+	if c := h.cmp(h.items[i].value, h.items[j].value); c != 0 {
+		return c < 0
+	}
+
+	return h.items[i].index < h.items[j].index
+}
+
+func (h *mergeHeap[V]) Swap(i, j int) {
+	h.items[i], h.items[j] = h.items[j], h.items[i]
+}
+
+func (h *mergeHeap[V]) Push(x any) {
+	h.items = append(h.items, x.(mergeItem[V]))
+}
+
+func (h *mergeHeap[V]) Pop() any {
+	n := len(h.items)
+	item := h.items[n-1]
+	h.items = h.items[:n-1]
+
+	return item
+}
+
+// MemoizeSeq works like ConvertSeq but calls fn only once per distinct input
+// value. The cache lives for one iteration and grows with the number of
+// distinct values; it isn't bounded.
+func MemoizeSeq[S comparable, T any](s iter.Seq[S], fn func(S) T) iter.Seq[T] {
+	return func(yield func(s T) bool) {
+		cache := map[S]T{}
+
+		for v := range s {
+			r, ok := cache[v]
+			if !ok {
+				r = fn(v)
+				cache[v] = r
+			}
+
+			if !yield(r) {
+				return
+			}
+		}
+	}
+}
+
+func LimitSeq[V any](s iter.Seq[V], n int) iter.Seq[V] {
+	return func(yield func(s V) bool) {
+		if n <= 0 {
+			return
+		}
+
+		i := 0
+
+		for v := range s {
+			if !yield(v) {
+				return
+			}
+
+			i++
+			if i >= n {
+				return
+			}
+		}
+	}
+}
+
+// WithDeadlineSeq stops yielding once d has elapsed since the iteration began.
+// The time is checked whenever the source produces an element, so a slow
+// source can overrun the deadline by the time it takes to produce it.
+func WithDeadlineSeq[V any](s iter.Seq[V], d time.Duration) iter.Seq[V] {
+	return func(yield func(s V) bool) {
+		deadline := time.Now().Add(d)
+
+		for v := range s {
+			if !time.Now().Before(deadline) {
+				return
+			}
+
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// SumFuncSeqRetry works like SumFuncSeq but calls fn up to attempts times per
+// element before it gives up and returns the last error.
+func SumFuncSeqRetry[S any, T cmp.Ordered](s iter.Seq[S], attempts int, fn func(S) (T, error)) (T, error) {
+	attempts = max(attempts, 1)
+
+	return SumFuncSeq(s, func(v S) (T, error) {
+		var val T
+		var err error
+
+		for range attempts {
+			val, err = fn(v)
+			if err == nil {
+				break
+			}
+		}
+
+		return val, err
+	})
+}
+
+func CollectN[V any](s iter.Seq[V], sizeHint int) []V {
+	return slices.AppendSeq(make([]V, 0, max(sizeHint, 0)), s)
+}
+
+// DistinctSeq yields the first element for every distinct key. Memory grows
+// with the number of distinct keys.
+func DistinctSeq[V any, K comparable](s iter.Seq[V], key func(V) K) iter.Seq[V] {
+	return func(yield func(s V) bool) {
+		seen := map[K]struct{}{}
+
+		for v := range s {
+			k := key(v)
+			if _, ok := seen[k]; ok {
+				continue
+			}
+
+			seen[k] = struct{}{}
+
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// Peekable wraps a sequence and allows to look at the next element without
+// consuming it. Stop releases the underlying sequence if it isn't consumed
+// completely.
+type Peekable[V any] struct {
+	next   func() (V, bool)
+	stop   func()
+	head   V
+	ok     bool
+	peeked bool
+}
+
+func NewPeekable[V any](s iter.Seq[V]) *Peekable[V] {
+	next, stop := iter.Pull(s)
+
+	return &Peekable[V]{
+		next: next,
+		stop: stop,
+	}
+}
+
+func (p *Peekable[V]) Next() (V, bool) {
+	if p.peeked {
+		p.peeked = false
+		return p.head, p.ok
+	}
+
+	return p.next()
+}
+
+func (p *Peekable[V]) Peek() (V, bool) {
+	if !p.peeked {
+		p.head, p.ok = p.next()
+		p.peeked = true
+	}
+
+	return p.head, p.ok
+}
+
+func (p *Peekable[V]) Stop() {
+	p.stop()
+}
+
+// Cache makes a one-shot sequence reusable. Elements are pulled from the source
+// only as far as a consumer iterates and are buffered, so later iterations
+// replay the buffer and continue with the source where the furthest one
+// stopped. The memory cost grows to the size of the source once it has been
+// fully iterated. If no iteration ever reaches the end, the source stays
+// suspended and is never stopped.
+func Cache[V any](s iter.Seq[V]) iter.Seq[V] {
+	var (
+		mu    sync.Mutex
+		items []V
+		next  func() (V, bool)
+		stop  func()
+		done  bool
+	)
+
+	return func(yield func(s V) bool) {
+		for i := 0; ; i++ {
+			mu.Lock()
+
+			if i >= len(items) {
+				if done {
+					mu.Unlock()
+					return
+				}
+
+				if next == nil {
+					next, stop = iter.Pull(s)
+				}
+
+				v, ok := next()
+				if !ok {
+					done = true
+					stop()
+					mu.Unlock()
+
+					return
+				}
+
+				items = append(items, v)
+			}
+
+			v := items[i]
+			mu.Unlock()
+
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+func FilterSeq2[K, V any](s iter.Seq2[K, V], f func(K, V) bool) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for k, v := range s {
+			if f(k, v) {
+				if !yield(k, v) {
+					return
+				}
+			}
+		}
+	}
+}
+
+func MapSeq2[K, V, K2, V2 any](s iter.Seq2[K, V], f func(K, V) (K2, V2)) iter.Seq2[K2, V2] {
+	return func(yield func(K2, V2) bool) {
+		for k, v := range s {
+			if !yield(f(k, v)) {
+				return
+			}
+		}
+	}
+}
+
+func KeysOf[K, V any](s iter.Seq2[K, V]) iter.Seq[K] {
+	return func(yield func(K) bool) {
+		for k := range s {
+			if !yield(k) {
+				return
+			}
+		}
+	}
+}
+
+func ValuesOf[K, V any](s iter.Seq2[K, V]) iter.Seq[V] {
+	return func(yield func(V) bool) {
+		for _, v := range s {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// FilterMapSeq transforms every element with f and yields the result only if f
+// also returns true, so the keep decision and the transformation share one
+// call.
+func FilterMapSeq[S any, T any](s iter.Seq[S], f func(S) (T, bool)) iter.Seq[T] {
+	return func(yield func(s T) bool) {
+		for v := range s {
+			if r, ok := f(v); ok {
+				if !yield(r) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// MapSeq is an alias for ConvertSeq.
+func MapSeq[S any, T any](s iter.Seq[S], fn func(val S) T) iter.Seq[T] {
+	return ConvertSeq(s, fn)
+}
+
+// ZipWithSeq combines the elements of a and b pairwise with f and stops when
+// either sequence is exhausted.
+func ZipWithSeq[A any, B any, C any](a iter.Seq[A], b iter.Seq[B], f func(A, B) C) iter.Seq[C] {
+	return func(yield func(C) bool) {
+		next, stop := iter.Pull(b)
+		defer stop()
+
+		for va := range a {
+			vb, ok := next()
+			if !ok {
+				return
+			}
+
+			if !yield(f(va, vb)) {
+				return
+			}
+		}
+	}
+}
+
+// ReverseSeq yields the elements of s in reverse order. The whole sequence is
+// buffered before the first element is yielded.
+func ReverseSeq[V any](s iter.Seq[V]) iter.Seq[V] {
+	return func(yield func(V) bool) {
+		items := slices.Collect(s)
+
+		for i := len(items) - 1; i >= 0; i-- {
+			if !yield(items[i]) {
+				return
+			}
+		}
+	}
+}
+
+// ReverseSeq2 yields the pairs of s in reverse order. The whole sequence is
+// buffered before the first pair is yielded.
+func ReverseSeq2[K, V any](s iter.Seq2[K, V]) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		var keys []K
+		var values []V
+
+		for k, v := range s {
+			keys = append(keys, k)
+			values = append(values, v)
+		}
+
+		for i := len(keys) - 1; i >= 0; i-- {
+			if !yield(keys[i], values[i]) {
+				return
+			}
+		}
+	}
+}
+
+// FindLastSeq returns the last element of s that satisfies f. It always
+// consumes the whole sequence.
+func FindLastSeq[V any](s iter.Seq[V], f func(val V) bool) (V, bool) {
+	var result V
+	found := false
+
+	for v := range s {
+		if f(v) {
+			result = v
+			found = true
+		}
+	}
+
+	return result, found
+}
+
+func IntersperseSeq[V any](s iter.Seq[V], sep V) iter.Seq[V] {
+	return func(yield func(V) bool) {
+		first := true
+
+		for v := range s {
+			if !first {
+				if !yield(sep) {
+					return
+				}
+			}
+
+			first = false
+
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// GroupConsecutiveSeq yields runs of consecutive elements. A new group starts
+// whenever sameGroup returns false for an element and its predecessor.
+func GroupConsecutiveSeq[V any](s iter.Seq[V], sameGroup func(prev, curr V) bool) iter.Seq[[]V] {
+	return func(yield func([]V) bool) {
+		var group []V
+
+		for v := range s {
+			if len(group) > 0 && !sameGroup(group[len(group)-1], v) {
+				if !yield(group) {
+					return
+				}
+
+				group = nil
+			}
+
+			group = append(group, v)
+		}
+
+		if len(group) > 0 {
+			yield(group)
+		}
+	}
+}
+
+// DeduplicateCountSeq yields every distinct value once together with the
+// number of its occurrences, in order of first appearance. The whole sequence
+// is consumed and counted before the first pair is yielded.
+func DeduplicateCountSeq[V comparable](s iter.Seq[V]) iter.Seq2[V, int] {
+	return func(yield func(V, int) bool) {
+		counts := map[V]int{}
+		var order []V
+
+		for v := range s {
+			if _, ok := counts[v]; !ok {
+				order = append(order, v)
+			}
+
+			counts[v]++
+		}
+
+		for _, v := range order {
+			if !yield(v, counts[v]) {
+				return
+			}
+		}
+	}
+}
+
+func ContainsSeq[V any](s iter.Seq[V], f func(V) bool) bool {
+	for v := range s {
+		if f(v) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ExistsSeq is an alias for ContainsSeq.
+func ExistsSeq[V any](s iter.Seq[V], f func(V) bool) bool {
+	return ContainsSeq(s, f)
+}
+
+func AllSeq[V any](s iter.Seq[V], f func(V) bool) bool {
+	return !ContainsSeq(s, func(v V) bool { return !f(v) })
+}
+
+func NoneSeq[V any](s iter.Seq[V], f func(V) bool) bool {
+	return !ContainsSeq(s, f)
+}
+
+// ChunkReduceSeq folds every chunk of size consecutive elements, starting from
+// seed, and yields one accumulator per chunk. The last chunk may be smaller.
+// A size < 1 folds the whole sequence into one value. The same seed starts
+// every chunk, so f must not mutate it: a slice seed with spare capacity, a
+// map or a pointer would be shared by all results.
+func ChunkReduceSeq[V any, A any](s iter.Seq[V], size int, seed A, f func(acc A, val V) A) iter.Seq[A] {
+	return func(yield func(A) bool) {
+		acc := seed
+		n := 0
+
+		for v := range s {
+			acc = f(acc, v)
+			n++
+
+			if size > 0 && n == size {
+				if !yield(acc) {
+					return
+				}
+
+				acc = seed
+				n = 0
+			}
+		}
+
+		if n > 0 {
+			yield(acc)
+		}
+	}
+}
+
+// CompactSeq drops consecutive runs of equal elements and keeps the first of
+// each run. Only the previous element is remembered.
+func CompactSeq[V comparable](s iter.Seq[V]) iter.Seq[V] {
+	return func(yield func(V) bool) {
+		var prev V
+		first := true
+
+		for v := range s {
+			if !first && v == prev {
+				continue
+			}
+
+			first = false
+			prev = v
+
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+func ConvertIndexSeq[S any, T any](s iter.Seq[S], f func(i int, val S) T) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		i := 0
+
+		for v := range s {
+			if !yield(f(i, v)) {
+				return
+			}
+
+			i++
+		}
+	}
+}
+
+// FilterOkSeq yields the values of s that come without an error. Each error is
+// passed to onErr instead; the iteration continues if onErr returns true and
+// stops otherwise. A nil onErr stops at the first error.
+func FilterOkSeq[V any](s iter.Seq2[V, error], onErr func(error) bool) iter.Seq[V] {
+	return func(yield func(V) bool) {
+		for v, err := range s {
+			if err != nil {
+				if onErr == nil || !onErr(err) {
+					return
+				}
+
+				continue
+			}
+
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+func CountFuncSeq[V any](s iter.Seq[V], f func(V) bool) int {
+	var result int
+
+	for v := range s {
+		if f(v) {
+			result++
+		}
+	}
+
+	return result
+}
+
+// GroupPairsSeq groups the elements of s by the key returned by fn and yields
+// every key with its group. The groups are yielded in random order.
+func GroupPairsSeq[E any, H comparable](s iter.Seq[E], fn func(E) H) iter.Seq2[H, []E] {
+	return func(yield func(H, []E) bool) {
+		for k, v := range groupMap(s, fn) {
+			if !yield(k, v) {
+				return
+			}
+		}
+	}
+}
+
+// GroupPairsSortedSeq works like GroupPairsSeq but yields the groups in
+// ascending key order.
+func GroupPairsSortedSeq[E any, H cmp.Ordered](s iter.Seq[E], fn func(E) H) iter.Seq2[H, []E] {
+	return func(yield func(H, []E) bool) {
+		groups := groupMap(s, fn)
+
+		for _, k := range slices.Sorted(maps.Keys(groups)) {
+			if !yield(k, groups[k]) {
+				return
+			}
+		}
+	}
+}
+
+func groupMap[E any, H comparable](s iter.Seq[E], fn func(E) H) map[H][]E {
+	groups := map[H][]E{}
+
+	for v := range s {
+		h := fn(v)
+		groups[h] = append(groups[h], v)
+	}
+
+	return groups
+}
+
+// ReplaceOrFuncSeq replaces the elements found in g by the mapped value and
+// all other elements by the result of fallback.
+func ReplaceOrFuncSeq[S comparable](s iter.Seq[S], g map[S]S, fallback func(S) S) iter.Seq[S] {
+	return ReplaceFuncSeq(s, func(v S) S {
+		if r, ok := g[v]; ok {
+			return r
+		}
+
+		return fallback(v)
+	})
+}
+
+// SumCheckedSeq sums the elements of s and returns ErrOverflow as soon as an
+// addition would exceed the range of V.
+func SumCheckedSeq[V Integer](s iter.Seq[V]) (V, error) {
+	var result V
+
+	for v := range s {
+		sum := result + v
+		if (v > 0 && sum < result) || (v < 0 && sum > result) {
+			return *new(V), ErrOverflow
+		}
+
+		result = sum
+	}
+
+	return result, nil
+}
+
+func CumSumSeq[V Number](s iter.Seq[V]) iter.Seq[V] {
+	return func(yield func(V) bool) {
+		var sum V
+
+		for v := range s {
+			sum += v
+
+			if !yield(sum) {
+				return
+			}
+		}
+	}
+}
+
+func FilterTakeSeq[V any](s iter.Seq[V], n int, f func(V) bool) iter.Seq[V] {
+	return LimitSeq(FilterSeq(s, f), n)
+}
+
+func StringsSeq[V any](s iter.Seq[V]) iter.Seq[string] {
+	return ConvertSeq(s, func(v V) string {
+		return stringify(v)
+	})
+}
+
+// HasPrefixSeq reports whether s starts with the elements of prefix. It pulls
+// at most len(prefix) elements from s.
+func HasPrefixSeq[V comparable](s iter.Seq[V], prefix []V) bool {
+	if len(prefix) == 0 {
+		return true
+	}
+
+	i := 0
+
+	for v := range s {
+		if v != prefix[i] {
+			return false
+		}
+
+		i++
+		if i == len(prefix) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// DeduplicateWindowSeq drops elements that are among the last window distinct
+// values seen. The values are kept in LRU order, a repeat refreshes its
+// position, and the least recently seen value is forgotten when the window is
+// full. A window < 1 disables the deduplication.
+func DeduplicateWindowSeq[V comparable](s iter.Seq[V], window int) iter.Seq[V] {
+	return func(yield func(V) bool) {
+		order := list.New()
+		seen := map[V]*list.Element{}
+
+		for v := range s {
+			if e, ok := seen[v]; ok {
+				order.MoveToFront(e)
+				continue
+			}
+
+			if window > 0 {
+				seen[v] = order.PushFront(v)
+
+				if order.Len() > window {
+					oldest := order.Back()
+					order.Remove(oldest)
+					delete(seen, oldest.Value.(V))
+				}
+			}
+
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// MovingAverageSeq yields the average of the last window elements. Nothing is
+// yielded until the window is full, so the output has window-1 elements less
+// than the input. A window < 1 yields nothing. The sum is recomputed from the
+// window for every element, so a single large value doesn't leave rounding
+// errors in the following averages.
+func MovingAverageSeq[V Number](s iter.Seq[V], window int) iter.Seq[float64] {
+	return func(yield func(float64) bool) {
+		if window < 1 {
+			return
+		}
+
+		buf := make([]float64, window)
+		n := 0
+
+		for v := range s {
+			buf[n%window] = float64(v)
+			n++
+
+			if n < window {
+				continue
+			}
+
+			var sum float64
+			for _, x := range buf {
+				sum += x
+			}
+
+			if !yield(sum / float64(window)) {
+				return
+			}
+		}
+	}
+}
+
+// Pipe applies the operators to src in the given order.
+func Pipe[V any](src iter.Seq[V], ops ...func(iter.Seq[V]) iter.Seq[V]) iter.Seq[V] {
+	for _, op := range ops {
+		src = op(src)
+	}
+
+	return src
+}
+
+// TapSeq calls f for every element before it is passed on unchanged.
+func TapSeq[V any](s iter.Seq[V], f func(V)) iter.Seq[V] {
+	return func(yield func(V) bool) {
+		for v := range s {
+			f(v)
+
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+func Materialize[V any](s iter.Seq[V]) ([]V, int) {
+	r := CollectN(s, 0)
+	return r, len(r)
+}
+
+// Drain consumes s only for its side effects, e.g. those of TapSeq, and
+// returns the number of elements.
+func Drain[V any](s iter.Seq[V]) int {
+	return CountSeq(s)
+}
+
+// DistinctUntilChangedSeq is an alias for CompactSeq.
+func DistinctUntilChangedSeq[V comparable](s iter.Seq[V]) iter.Seq[V] {
+	return CompactSeq(s)
+}
+
+// DistinctUntilChangedFuncSeq yields an element only if eq reports it as
+// different from the previously yielded element.
+func DistinctUntilChangedFuncSeq[V any](s iter.Seq[V], eq func(a, b V) bool) iter.Seq[V] {
+	return func(yield func(V) bool) {
+		var last V
+		first := true
+
+		for v := range s {
+			if !first && eq(last, v) {
+				continue
+			}
+
+			first = false
+			last = v
+
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// ScanErrSeq folds s starting from seed and yields the accumulator after every
+// step. If f returns an error, the accumulator stays unchanged and is yielded
+// together with the error; the consumer decides whether to continue.
+func ScanErrSeq[S any, A any](s iter.Seq[S], seed A, f func(A, S) (A, error)) iter.Seq2[A, error] {
+	return func(yield func(A, error) bool) {
+		acc := seed
+
+		for v := range s {
+			next, err := f(acc, v)
+			if err == nil {
+				acc = next
+			}
+
+			if !yield(acc, err) {
+				return
+			}
+		}
+	}
+}
+
+// GroupSeqCapped groups the elements of s by the key returned by fn but keeps
+// at most maxGroups groups in memory. When a new key would exceed the limit,
+// the oldest group, the one whose key appeared first, is yielded and evicted.
+// A later element with an evicted key starts a new group, so a key can be
+// yielded more than once. The remaining groups are yielded in order of their
+// creation when s is exhausted. A maxGroups < 1 is treated as 1.
+func GroupSeqCapped[E any, H comparable](s iter.Seq[E], fn func(E) H, maxGroups int) iter.Seq2[H, []E] {
+	type group struct {
+		key   H
+		items []E
+	}
+
+	maxGroups = max(maxGroups, 1)
+
+	return func(yield func(H, []E) bool) {
+		order := list.New()
+		groups := map[H]*list.Element{}
+
+		for v := range s {
+			h := fn(v)
+
+			if e, ok := groups[h]; ok {
+				g := e.Value.(*group)
+				g.items = append(g.items, v)
+				continue
+			}
+
+			if order.Len() >= maxGroups {
+				oldest := order.Remove(order.Front()).(*group)
+				delete(groups, oldest.key)
+
+				if !yield(oldest.key, oldest.items) {
+					return
+				}
+			}
+
+			groups[h] = order.PushBack(&group{key: h, items: []E{v}})
+		}
+
+		for e := order.Front(); e != nil; e = e.Next() {
+			g := e.Value.(*group)
+			if !yield(g.key, g.items) {
+				return
+			}
+		}
+	}
+}
+
+type JoinPair[A, B any] struct {
+	L A
+	R B
+}
+
+// JoinSeq2 performs an inner join of left and right on their keys. Left keys
+// without a match in right are dropped, a key that occurs several times in
+// right yields one pair per match. The right sequence is fully buffered
+// before the first pair is yielded.
+func JoinSeq2[K comparable, A, B any](left iter.Seq2[K, A], right iter.Seq2[K, B]) iter.Seq2[K, JoinPair[A, B]] {
+	return func(yield func(K, JoinPair[A, B]) bool) {
+		lookup := map[K][]B{}
+		for k, b := range right {
+			lookup[k] = append(lookup[k], b)
+		}
+
+		for k, a := range left {
+			for _, b := range lookup[k] {
+				if !yield(k, JoinPair[A, B]{L: a, R: b}) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// SumFuncSeqAll works like SumFuncSeq but doesn't stop at an error. It returns
+// the sum of all elements without an error together with every error.
+func SumFuncSeqAll[S any, T Number](s iter.Seq[S], fn func(S) (T, error)) (T, []error) {
+	var result T
+	var errs []error
+
+	for v := range s {
+		val, err := fn(v)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		result += val
+	}
+
+	return result, errs
+}
+
+// ConvertWhileSeq transforms the elements with f until it returns false. The
+// element that returned false isn't yielded and the source is released.
+func ConvertWhileSeq[S any, T any](s iter.Seq[S], f func(S) (T, bool)) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for v := range s {
+			r, ok := f(v)
+			if !ok {
+				return
+			}
+
+			if !yield(r) {
+				return
+			}
+		}
+	}
+}
+
+// MarkDuplicatesSeq yields every element together with true if an equal
+// element was seen before. Memory grows with the number of distinct values.
+func MarkDuplicatesSeq[V comparable](s iter.Seq[V]) iter.Seq2[V, bool] {
+	return func(yield func(V, bool) bool) {
+		seen := map[V]struct{}{}
+
+		for v := range s {
+			_, dup := seen[v]
+			seen[v] = struct{}{}
+
+			if !yield(v, dup) {
+				return
+			}
+		}
+	}
+}
+
+func ReplaceWhereSeq[V any](s iter.Seq[V], match func(V) bool, repl V) iter.Seq[V] {
+	return ReplaceFuncSeq(s, func(v V) V {
+		if match(v) {
+			return repl
+		}
+
+		return v
+	})
+}
+
+type ValueCount[V comparable] struct {
+	Value V
+	Count int
+}
+
+// TopFrequentSeq returns the n most frequent values of s in descending order
+// of their count, ties are ordered by first appearance. It consumes the whole
+// sequence and keeps a counter for every distinct value.
+func TopFrequentSeq[V comparable](s iter.Seq[V], n int) []ValueCount[V] {
+	result := []ValueCount[V]{}
+
+	for v, c := range DeduplicateCountSeq(s) {
+		result = append(result, ValueCount[V]{Value: v, Count: c})
+	}
+
+	slices.SortStableFunc(result, func(a, b ValueCount[V]) int {
+		return b.Count - a.Count
+	})
+
+	return result[:min(max(n, 0), len(result))]
+}
+
+// BatchTimeSeq groups the elements of s into batches that are yielded when
+// they hold maxSize elements or when maxWait has elapsed since the first
+// element of the batch arrived. Because s is pulled, the time is only checked
+// when an element arrives, so an idle source delays the flush until its next
+// element or its end. A maxSize < 1 or maxWait <= 0 disables the
+// corresponding limit.
+func BatchTimeSeq[V any](s iter.Seq[V], maxSize int, maxWait time.Duration) iter.Seq[[]V] {
+	return func(yield func([]V) bool) {
+		var batch []V
+		var started time.Time
+
+		for v := range s {
+			if len(batch) == 0 {
+				started = time.Now()
+			}
+
+			batch = append(batch, v)
+
+			if (maxSize > 0 && len(batch) >= maxSize) || (maxWait > 0 && time.Since(started) >= maxWait) {
+				if !yield(batch) {
+					return
+				}
+
+				batch = nil
+			}
+		}
+
+		if len(batch) > 0 {
+			yield(batch)
+		}
+	}
+}
+
+// DropLastSeq yields all but the last n elements of s. It holds back a window
+// of n elements and yields an element once n newer ones have arrived.
+func DropLastSeq[V any](s iter.Seq[V], n int) iter.Seq[V] {
+	return func(yield func(V) bool) {
+		if n <= 0 {
+			for v := range s {
+				if !yield(v) {
+					return
+				}
+			}
+
+			return
+		}
+
+		buf := make([]V, n)
+		i := 0
+
+		for v := range s {
+			if i >= n {
+				if !yield(buf[i%n]) {
+					return
+				}
+			}
+
+			buf[i%n] = v
+			i++
+		}
+	}
+}
+
+// ChunkMapSeq collects chunks of size consecutive elements and yields the
+// result of f for each chunk. The last chunk may be smaller, a size < 1
+// collects the whole sequence into one chunk.
+func ChunkMapSeq[V any, T any](s iter.Seq[V], size int, f func(chunk []V) T) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		var chunk []V
+
+		for v := range s {
+			chunk = append(chunk, v)
+
+			if size > 0 && len(chunk) == size {
+				if !yield(f(chunk)) {
+					return
+				}
+
+				chunk = nil
+			}
+		}
+
+		if len(chunk) > 0 {
+			yield(f(chunk))
+		}
+	}
+}
+
+func JoinSeq[V any](s iter.Seq[V], sep string, toStr func(V) string) string {
+	var sb strings.Builder
+
+	first := true
+	for v := range s {
+		if !first {
+			sb.WriteString(sep)
+		}
+
+		first = false
+		sb.WriteString(toStr(v))
+	}
+
+	return sb.String()
+}
+
+func JoinStringerSeq[V fmt.Stringer](s iter.Seq[V], sep string) string {
+	return JoinSeq(s, sep, V.String)
+}
+
+// SlidingChunkSeq yields windows of size elements, each starting step
+// elements after the previous one. Only complete windows are yielded, so
+// trailing elements that don't fill a window are dropped. If step is greater
+// than size, the elements in between are skipped; a step <= 0 is treated as 1
+// and a size < 1 yields nothing.
+func SlidingChunkSeq[V any](s iter.Seq[V], size, step int) iter.Seq[[]V] {
+	step = max(step, 1)
+
+	return func(yield func([]V) bool) {
+		if size < 1 {
+			return
+		}
+
+		window := make([]V, 0, size)
+		skip := 0
+
+		for v := range s {
+			if skip > 0 {
+				skip--
+				continue
+			}
+
+			window = append(window, v)
+			if len(window) < size {
+				continue
+			}
+
+			if !yield(slices.Clone(window)) {
+				return
+			}
+
+			if step >= size {
+				window = window[:0]
+				skip = step - size
+			} else {
+				window = append(window[:0], window[step:]...)
+			}
+		}
+	}
+}
+
+// PrependSeq yields vals followed by the elements of s.
+func PrependSeq[V any](s iter.Seq[V], vals ...V) iter.Seq[V] {
+	return func(yield func(V) bool) {
+		for _, v := range vals {
+			if !yield(v) {
+				return
+			}
+		}
+
+		for v := range s {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// AppendSeq yields the elements of s followed by vals.
+func AppendSeq[V any](s iter.Seq[V], vals ...V) iter.Seq[V] {
+	return func(yield func(V) bool) {
+		for v := range s {
+			if !yield(v) {
+				return
+			}
+		}
+
+		for _, v := range vals {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
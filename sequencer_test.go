@@ -6,13 +6,19 @@
 package slice_utils_test
 
 import (
+	"bufio"
+	"bytes"
 	"errors"
 	"fmt"
+	"iter"
 	"maps"
+	"math"
 	"regexp"
 	"slices"
 	"strconv"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/zauberhaus/slice_utils"
@@ -213,6 +219,1189 @@ func TestConvertSeq(t *testing.T) {
 	assert.Equal(t, []string{"1", "2", "3"}, got)
 }
 
+func TestFilterIndexSeq(t *testing.T) {
+	data := []int{10, 11, 12, 13, 14}
+	seq := slice_utils.FilterIndexSeq(slices.Values(data), func(i int, v int) bool {
+		return i%2 == 1
+	})
+	got := slices.Collect(seq)
+	assert.Equal(t, []int{11, 13}, got)
+
+	// the index restarts for every iteration
+	assert.Equal(t, []int{11, 13}, slices.Collect(seq))
+
+	sample := slice_utils.FilterIndexSeq(slices.Values(make([]int, 35)), func(i int, v int) bool {
+		return i%10 == 0
+	})
+	assert.Equal(t, 4, slice_utils.CountSeq(sample))
+}
+
+func TestRejectSeq(t *testing.T) {
+	data := []int{1, 2, 3, 4, 5}
+	seq := slice_utils.RejectSeq(slices.Values(data), func(v int) bool {
+		return v%2 == 0
+	})
+	got := slices.Collect(seq)
+	assert.Equal(t, []int{1, 3, 5}, got)
+}
+
+type failingReader struct {
+	data string
+	err  error
+}
+
+func (r *failingReader) Read(p []byte) (int, error) {
+	if r.data == "" {
+		return 0, r.err
+	}
+
+	n := copy(p, r.data)
+	r.data = r.data[n:]
+
+	return n, nil
+}
+
+func TestLinesSeq(t *testing.T) {
+	t.Run("lines", func(t *testing.T) {
+		var got []string
+		for line, err := range slice_utils.LinesSeq(strings.NewReader("a\nb\r\n\nc")) {
+			assert.NoError(t, err)
+			got = append(got, line)
+		}
+		assert.Equal(t, []string{"a", "b", "", "c"}, got)
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		got := maps.Collect(slice_utils.LinesSeq(strings.NewReader("")))
+		assert.Empty(t, got)
+	})
+
+	t.Run("read error", func(t *testing.T) {
+		readErr := errors.New("read failed")
+		var lines []string
+		var errs []error
+		for line, err := range slice_utils.LinesSeq(&failingReader{data: "a\nb\n", err: readErr}) {
+			lines = append(lines, line)
+			errs = append(errs, err)
+		}
+		assert.Equal(t, []string{"a", "b", ""}, lines)
+		assert.Equal(t, []error{nil, nil, readErr}, errs)
+	})
+
+	t.Run("line too long", func(t *testing.T) {
+		var last error
+		for _, err := range slice_utils.LinesSeq(strings.NewReader(strings.Repeat("x", bufio.MaxScanTokenSize+1))) {
+			last = err
+		}
+		assert.ErrorIs(t, last, bufio.ErrTooLong)
+	})
+}
+
+type limitedWriter struct {
+	limit int
+	buf   bytes.Buffer
+}
+
+func (w *limitedWriter) Write(p []byte) (int, error) {
+	if w.buf.Len()+len(p) > w.limit {
+		return 0, errors.New("write limit reached")
+	}
+
+	return w.buf.Write(p)
+}
+
+func TestWriteJSONArray(t *testing.T) {
+	t.Run("values", func(t *testing.T) {
+		var buf bytes.Buffer
+		err := slice_utils.WriteJSONArray(&buf, slices.Values([]string{"a", "b", "c"}))
+		assert.NoError(t, err)
+		assert.Equal(t, `["a","b","c"]`, buf.String())
+	})
+
+	t.Run("structs", func(t *testing.T) {
+		type item struct {
+			ID   int    `json:"id"`
+			Name string `json:"name"`
+		}
+
+		var buf bytes.Buffer
+		err := slice_utils.WriteJSONArray(&buf, slices.Values([]item{{1, "a"}, {2, "b"}}))
+		assert.NoError(t, err)
+		assert.JSONEq(t, `[{"id":1,"name":"a"},{"id":2,"name":"b"}]`, buf.String())
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		var buf bytes.Buffer
+		err := slice_utils.WriteJSONArray(&buf, slices.Values([]int{}))
+		assert.NoError(t, err)
+		assert.Equal(t, `[]`, buf.String())
+	})
+
+	t.Run("encode error", func(t *testing.T) {
+		var buf bytes.Buffer
+		err := slice_utils.WriteJSONArray(&buf, slices.Values([]any{1, func() {}, 3}))
+		assert.Error(t, err)
+		assert.Equal(t, `[1`, buf.String())
+	})
+
+	t.Run("write error aborts iteration", func(t *testing.T) {
+		count := 0
+		seq := slice_utils.ReplaceFuncSeq(slices.Values([]int{1, 2, 3, 4}), func(v int) int {
+			count++
+			return v
+		})
+
+		w := &limitedWriter{limit: 4}
+		err := slice_utils.WriteJSONArray(w, seq)
+		assert.Error(t, err)
+		assert.Equal(t, `[1,2`, w.buf.String())
+		assert.Equal(t, 3, count)
+	})
+}
+
+func TestWriteCSVSeq(t *testing.T) {
+	t.Run("records", func(t *testing.T) {
+		var buf bytes.Buffer
+		err := slice_utils.WriteCSVSeq(&buf, slices.Values([]int{1, 2}), []string{"value", "square"}, func(v int) []string {
+			return []string{strconv.Itoa(v), strconv.Itoa(v * v)}
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, "value,square\n1,1\n2,4\n", buf.String())
+	})
+
+	t.Run("write error", func(t *testing.T) {
+		w := &limitedWriter{limit: 4}
+		err := slice_utils.WriteCSVSeq(w, slices.Values([]int{1, 2, 3}), []string{"value"}, func(v int) []string {
+			return []string{strconv.Itoa(v)}
+		})
+		assert.Error(t, err)
+	})
+}
+
+func TestClampSeq(t *testing.T) {
+	data := []float64{-1.5, 0.5, 2.5}
+	seq := slice_utils.ClampSeq(slices.Values(data), 0, 1)
+	got := slices.Collect(seq)
+	assert.Equal(t, []float64{0, 0.5, 1}, got)
+}
+
+func TestRunningMaxSeq(t *testing.T) {
+	data := []int{2, 8, 4, 9, 1}
+	seq := slice_utils.RunningMaxSeq(slices.Values(data))
+	assert.Equal(t, []int{2, 8, 8, 9, 9}, slices.Collect(seq))
+	assert.Equal(t, []int{2, 8, 8, 9, 9}, slices.Collect(seq))
+}
+
+func TestRunningMinSeq(t *testing.T) {
+	data := []int{5, 8, 4, 9, 1}
+	seq := slice_utils.RunningMinSeq(slices.Values(data))
+	assert.Equal(t, []int{5, 5, 4, 4, 1}, slices.Collect(seq))
+}
+
+func TestSortSeq(t *testing.T) {
+	data := []int{3, 1, 2}
+	seq := slice_utils.SortSeq(slices.Values(data))
+	assert.Equal(t, []int{1, 2, 3}, slices.Collect(seq))
+	assert.Equal(t, []int{3, 1, 2}, data)
+}
+
+func TestSortFuncSeq(t *testing.T) {
+	data := []string{"bb", "a", "ccc", "dd"}
+	seq := slice_utils.SortFuncSeq(slices.Values(data), func(a, b string) bool {
+		return len(a) < len(b)
+	})
+	assert.Equal(t, []string{"a", "bb", "dd", "ccc"}, slices.Collect(seq))
+}
+
+func TestKWayMergeSeq(t *testing.T) {
+	t.Run("merge", func(t *testing.T) {
+		seq := slice_utils.KWayMergeSeq(
+			slices.Values([]int{1, 4, 7}),
+			slices.Values([]int{2, 5, 8}),
+			slices.Values([]int{}),
+			slices.Values([]int{0, 3, 6, 9, 10}),
+		)
+		assert.Equal(t, []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10}, slices.Collect(seq))
+	})
+
+	t.Run("no input", func(t *testing.T) {
+		assert.Empty(t, slices.Collect(slice_utils.KWayMergeSeq[int]()))
+	})
+}
+
+func TestKWayMergeFuncSeq(t *testing.T) {
+	type event struct {
+		Time  int
+		Shard string
+	}
+
+	seq := slice_utils.KWayMergeFuncSeq(func(a, b event) int { return a.Time - b.Time },
+		slices.Values([]event{{1, "a"}, {3, "a"}}),
+		slices.Values([]event{{1, "b"}, {2, "b"}}),
+	)
+	assert.Equal(t, []event{{1, "a"}, {1, "b"}, {2, "b"}, {3, "a"}}, slices.Collect(seq))
+}
+
+func TestMemoizeSeq(t *testing.T) {
+	data := []string{"a", "b", "a", "c", "b"}
+	calls := 0
+	seq := slice_utils.MemoizeSeq(slices.Values(data), func(v string) string {
+		calls++
+		return strings.ToUpper(v)
+	})
+	assert.Equal(t, []string{"A", "B", "A", "C", "B"}, slices.Collect(seq))
+	assert.Equal(t, 3, calls)
+}
+
+func TestLimitSeq(t *testing.T) {
+	data := []int{1, 2, 3, 4, 5}
+	assert.Equal(t, []int{1, 2, 3}, slices.Collect(slice_utils.LimitSeq(slices.Values(data), 3)))
+	assert.Equal(t, data, slices.Collect(slice_utils.LimitSeq(slices.Values(data), 10)))
+	assert.Empty(t, slices.Collect(slice_utils.LimitSeq(slices.Values(data), 0)))
+
+	pulled := 0
+	src := slice_utils.ReplaceFuncSeq(slices.Values(data), func(v int) int {
+		pulled++
+		return v
+	})
+	assert.Equal(t, []int{1, 2}, slices.Collect(slice_utils.LimitSeq(src, 2)))
+	assert.Equal(t, 2, pulled)
+}
+
+func TestWithDeadlineSeq(t *testing.T) {
+	t.Run("within deadline", func(t *testing.T) {
+		data := []int{1, 2, 3}
+		seq := slice_utils.WithDeadlineSeq(slices.Values(data), time.Hour)
+		assert.Equal(t, data, slices.Collect(seq))
+	})
+
+	t.Run("deadline exceeded", func(t *testing.T) {
+		data := []int{1, 2, 3, 4}
+		slow := slice_utils.ReplaceFuncSeq(slices.Values(data), func(v int) int {
+			if v == 3 {
+				time.Sleep(20 * time.Millisecond)
+			}
+			return v
+		})
+		seq := slice_utils.WithDeadlineSeq(slow, 10*time.Millisecond)
+		assert.Equal(t, []int{1, 2}, slices.Collect(seq))
+	})
+
+	t.Run("expired", func(t *testing.T) {
+		seq := slice_utils.WithDeadlineSeq(slices.Values([]int{1, 2}), 0)
+		assert.Empty(t, slices.Collect(seq))
+	})
+}
+
+func TestSumFuncSeqRetry(t *testing.T) {
+	t.Run("transient failures", func(t *testing.T) {
+		failures := map[int]int{2: 2, 3: 1}
+		got, err := slice_utils.SumFuncSeqRetry(slices.Values([]int{1, 2, 3}), 3, func(v int) (int, error) {
+			if failures[v] > 0 {
+				failures[v]--
+				return 0, errors.New("flaky")
+			}
+			return v, nil
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, 6, got)
+	})
+
+	t.Run("attempts exhausted", func(t *testing.T) {
+		calls := 0
+		got, err := slice_utils.SumFuncSeqRetry(slices.Values([]int{1, 2, 3}), 2, func(v int) (int, error) {
+			calls++
+			if v == 2 {
+				return 0, errors.New("broken")
+			}
+			return v, nil
+		})
+		assert.EqualError(t, err, "broken")
+		assert.Equal(t, 0, got)
+		assert.Equal(t, 3, calls)
+	})
+
+	t.Run("at least one attempt", func(t *testing.T) {
+		got, err := slice_utils.SumFuncSeqRetry(slices.Values([]int{1, 2}), 0, func(v int) (int, error) {
+			return v, nil
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, 3, got)
+	})
+}
+
+func TestCollectN(t *testing.T) {
+	data := []int{1, 2, 3}
+
+	got := slice_utils.CollectN(slices.Values(data), 10)
+	assert.Equal(t, data, got)
+	assert.Equal(t, 10, cap(got))
+
+	got = slice_utils.CollectN(slices.Values(data), 1)
+	assert.Equal(t, data, got)
+
+	got = slice_utils.CollectN(slices.Values([]int{}), -1)
+	assert.NotNil(t, got)
+	assert.Empty(t, got)
+}
+
+func TestDistinctSeq(t *testing.T) {
+	type event struct {
+		ID   int
+		Name string
+	}
+
+	data := []event{{1, "a"}, {2, "b"}, {1, "c"}, {3, "d"}, {2, "e"}}
+	seq := slice_utils.DistinctSeq(slices.Values(data), func(e event) int { return e.ID })
+	assert.Equal(t, []event{{1, "a"}, {2, "b"}, {3, "d"}}, slices.Collect(seq))
+	assert.Equal(t, []event{{1, "a"}, {2, "b"}, {3, "d"}}, slices.Collect(seq))
+}
+
+func TestPeekable(t *testing.T) {
+	t.Run("peek and next", func(t *testing.T) {
+		p := slice_utils.NewPeekable(slices.Values([]string{"a", "b"}))
+		defer p.Stop()
+
+		v, ok := p.Peek()
+		assert.True(t, ok)
+		assert.Equal(t, "a", v)
+
+		v, ok = p.Peek()
+		assert.True(t, ok)
+		assert.Equal(t, "a", v)
+
+		v, ok = p.Next()
+		assert.True(t, ok)
+		assert.Equal(t, "a", v)
+
+		v, ok = p.Next()
+		assert.True(t, ok)
+		assert.Equal(t, "b", v)
+
+		_, ok = p.Peek()
+		assert.False(t, ok)
+
+		_, ok = p.Next()
+		assert.False(t, ok)
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		p := slice_utils.NewPeekable(slices.Values([]int{}))
+		defer p.Stop()
+
+		_, ok := p.Peek()
+		assert.False(t, ok)
+		_, ok = p.Next()
+		assert.False(t, ok)
+	})
+
+	t.Run("stop", func(t *testing.T) {
+		p := slice_utils.NewPeekable(slices.Values([]int{1, 2, 3}))
+		v, ok := p.Next()
+		assert.True(t, ok)
+		assert.Equal(t, 1, v)
+
+		p.Stop()
+
+		_, ok = p.Next()
+		assert.False(t, ok)
+	})
+}
+
+func TestCache(t *testing.T) {
+	t.Run("one-shot source", func(t *testing.T) {
+		next, stop := iter.Pull(slices.Values([]int{1, 2, 3}))
+		defer stop()
+
+		calls := 0
+		oneShot := func(yield func(int) bool) {
+			calls++
+			for {
+				v, ok := next()
+				if !ok || !yield(v) {
+					return
+				}
+			}
+		}
+
+		seq := slice_utils.Cache(oneShot)
+		assert.Equal(t, []int{1, 2, 3}, slices.Collect(seq))
+		assert.Equal(t, []int{1, 2, 3}, slices.Collect(seq))
+		assert.Equal(t, 1, calls)
+	})
+
+	t.Run("RemoveSeq", func(t *testing.T) {
+		ch := make(chan int, 2)
+		ch <- 2
+		ch <- 4
+		close(ch)
+
+		remove := slice_utils.Cache(func(yield func(int) bool) {
+			for v := range ch {
+				if !yield(v) {
+					return
+				}
+			}
+		})
+
+		seq := slice_utils.RemoveSeq(slices.Values([]int{1, 2, 3, 4, 5}), remove)
+		assert.Equal(t, []int{1, 3, 5}, slices.Collect(seq))
+	})
+
+	t.Run("unbounded source", func(t *testing.T) {
+		calls, pulled := 0, 0
+		naturals := func(yield func(int) bool) {
+			calls++
+			for i := 0; ; i++ {
+				pulled++
+				if !yield(i) {
+					return
+				}
+			}
+		}
+
+		seq := slice_utils.Cache(naturals)
+		assert.Equal(t, []int{0, 1, 2}, slices.Collect(slice_utils.LimitSeq(seq, 3)))
+		assert.Equal(t, 3, pulled)
+
+		assert.Equal(t, []int{0, 1, 2, 3, 4}, slices.Collect(slice_utils.LimitSeq(seq, 5)))
+		assert.Equal(t, 5, pulled)
+		assert.Equal(t, 1, calls)
+	})
+}
+
+func TestFilterSeq2(t *testing.T) {
+	seq := slice_utils.FilterSeq2(slices.All([]string{"a", "b", "c", "d"}), func(i int, v string) bool {
+		return i%2 == 0
+	})
+	assert.Equal(t, map[int]string{0: "a", 2: "c"}, maps.Collect(seq))
+}
+
+func TestMapSeq2(t *testing.T) {
+	seq := slice_utils.MapSeq2(slices.All([]string{"a", "b"}), func(i int, v string) (string, int) {
+		return v, i + 1
+	})
+	assert.Equal(t, map[string]int{"a": 1, "b": 2}, maps.Collect(seq))
+}
+
+func TestKeysOf(t *testing.T) {
+	seq := slice_utils.KeysOf(slices.All([]string{"a", "b", "c"}))
+	assert.Equal(t, []int{0, 1, 2}, slices.Collect(seq))
+
+	hashes := slices.Collect(slice_utils.KeysOf(slice_utils.HashSeq(slices.Values([]string{"a", "b"}))))
+	assert.Len(t, hashes, 2)
+}
+
+func TestValuesOf(t *testing.T) {
+	seq := slice_utils.ValuesOf(slice_utils.HashSeq(slices.Values([]string{"a", "b", "c"})))
+	assert.Equal(t, []string{"a", "b", "c"}, slices.Collect(seq))
+}
+
+func TestFilterMapSeq(t *testing.T) {
+	data := []string{"1", "a", "2"}
+	seq := slice_utils.FilterMapSeq(slices.Values(data), func(v string) (int, bool) {
+		i, err := strconv.Atoi(v)
+		return i * 10, err == nil
+	})
+	assert.Equal(t, []int{10, 20}, slices.Collect(seq))
+
+	calls := 0
+	parsed := slice_utils.FilterMapSeq(slices.Values([]string{"1.5", "x", "2"}), func(v string) (float64, bool) {
+		calls++
+		f, err := strconv.ParseFloat(v, 64)
+		return f, err == nil
+	})
+	assert.Equal(t, []float64{1.5, 2}, slices.Collect(parsed))
+	assert.Equal(t, 3, calls)
+}
+
+func TestMapSeq(t *testing.T) {
+	seq := slice_utils.MapSeq(slices.Values([]int{1, 2, 3}), strconv.Itoa)
+	assert.Equal(t, []string{"1", "2", "3"}, slices.Collect(seq))
+}
+
+func TestZipWithSeq(t *testing.T) {
+	add := func(a int, b int) int { return a + b }
+
+	seq := slice_utils.ZipWithSeq(slices.Values([]int{1, 2, 3}), slices.Values([]int{10, 20}), add)
+	assert.Equal(t, []int{11, 22}, slices.Collect(seq))
+
+	seq = slice_utils.ZipWithSeq(slices.Values([]int{1}), slices.Values([]int{10, 20}), add)
+	assert.Equal(t, []int{11}, slices.Collect(seq))
+}
+
+func TestReverseSeq(t *testing.T) {
+	seq := slice_utils.ReverseSeq(slices.Values([]int{1, 2, 3}))
+	assert.Equal(t, []int{3, 2, 1}, slices.Collect(seq))
+	assert.Empty(t, slices.Collect(slice_utils.ReverseSeq(slices.Values([]int{}))))
+}
+
+func TestReverseSeq2(t *testing.T) {
+	seq := slice_utils.ReverseSeq2(slices.All([]string{"a", "b", "c"}))
+
+	var keys []int
+	var values []string
+	for k, v := range seq {
+		keys = append(keys, k)
+		values = append(values, v)
+	}
+
+	assert.Equal(t, []int{2, 1, 0}, keys)
+	assert.Equal(t, []string{"c", "b", "a"}, values)
+}
+
+func TestFindLastSeq(t *testing.T) {
+	data := []int{1, 4, 3, 6, 5}
+	got, ok := slice_utils.FindLastSeq(slices.Values(data), func(v int) bool { return v%2 == 0 })
+	assert.True(t, ok)
+	assert.Equal(t, 6, got)
+
+	got, ok = slice_utils.FindLastSeq(slices.Values(data), func(v int) bool { return v > 10 })
+	assert.False(t, ok)
+	assert.Equal(t, 0, got)
+}
+
+func TestIntersperseSeq(t *testing.T) {
+	seq := slice_utils.IntersperseSeq(slices.Values([]string{"a", "b"}), ",")
+	assert.Equal(t, []string{"a", ",", "b"}, slices.Collect(seq))
+}
+
+func TestGroupConsecutiveSeq(t *testing.T) {
+	within5 := func(prev, curr int) bool { return curr-prev <= 5 }
+
+	seq := slice_utils.GroupConsecutiveSeq(slices.Values([]int{1, 3, 7, 20, 22, 40}), within5)
+	assert.Equal(t, [][]int{{1, 3, 7}, {20, 22}, {40}}, slices.Collect(seq))
+
+	seq = slice_utils.GroupConsecutiveSeq(slices.Values([]int{}), within5)
+	assert.Empty(t, slices.Collect(seq))
+}
+
+func TestDeduplicateCountSeq(t *testing.T) {
+	data := []string{"b", "a", "b", "c", "b", "a"}
+
+	var values []string
+	var counts []int
+	for v, c := range slice_utils.DeduplicateCountSeq(slices.Values(data)) {
+		values = append(values, v)
+		counts = append(counts, c)
+	}
+
+	assert.Equal(t, []string{"b", "a", "c"}, values)
+	assert.Equal(t, []int{3, 2, 1}, counts)
+}
+
+func TestContainsSeq(t *testing.T) {
+	pulled := 0
+	data := slice_utils.ReplaceFuncSeq(slices.Values([]int{1, 2, 3, 4}), func(v int) int {
+		pulled++
+		return v
+	})
+
+	assert.True(t, slice_utils.ContainsSeq(data, func(v int) bool { return v == 2 }))
+	assert.Equal(t, 2, pulled)
+
+	assert.False(t, slice_utils.ContainsSeq(data, func(v int) bool { return v > 4 }))
+	assert.False(t, slice_utils.ContainsSeq(slices.Values([]int{}), func(v int) bool { return true }))
+}
+
+func TestExistsSeq(t *testing.T) {
+	data := slices.Values([]int{1, 2, 3})
+	assert.True(t, slice_utils.ExistsSeq(data, func(v int) bool { return v == 3 }))
+	assert.False(t, slice_utils.ExistsSeq(data, func(v int) bool { return v == 4 }))
+}
+
+func TestAllSeq(t *testing.T) {
+	data := slices.Values([]int{2, 4, 6})
+	assert.True(t, slice_utils.AllSeq(data, func(v int) bool { return v%2 == 0 }))
+	assert.False(t, slice_utils.AllSeq(data, func(v int) bool { return v < 6 }))
+	assert.True(t, slice_utils.AllSeq(slices.Values([]int{}), func(v int) bool { return false }))
+}
+
+func TestNoneSeq(t *testing.T) {
+	data := slices.Values([]int{2, 4, 6})
+	assert.True(t, slice_utils.NoneSeq(data, func(v int) bool { return v%2 == 1 }))
+	assert.False(t, slice_utils.NoneSeq(data, func(v int) bool { return v == 4 }))
+	assert.True(t, slice_utils.NoneSeq(slices.Values([]int{}), func(v int) bool { return true }))
+}
+
+func TestChunkReduceSeq(t *testing.T) {
+	seq := slice_utils.ChunkReduceSeq(slices.Values([]string{"a", "b", "c"}), 2, "", func(acc string, val string) string {
+		return acc + val
+	})
+	assert.Equal(t, []string{"ab", "c"}, slices.Collect(seq))
+}
+
+func TestCompactSeq(t *testing.T) {
+	seq := slice_utils.CompactSeq(slices.Values([]int{0, 0, 1, 1, 1, 2, 1, 1}))
+	assert.Equal(t, []int{0, 1, 2, 1}, slices.Collect(seq))
+	assert.Empty(t, slices.Collect(slice_utils.CompactSeq(slices.Values([]int{}))))
+}
+
+func TestConvertIndexSeq(t *testing.T) {
+	lines := slices.Values([]string{"first", "second", "third"})
+	seq := slice_utils.ConvertIndexSeq(lines, func(i int, v string) string {
+		return strconv.Itoa(i+1) + " " + v
+	})
+	assert.Equal(t, []string{"1 first", "2 second", "3 third"}, slices.Collect(seq))
+	assert.Equal(t, []string{"1 first", "2 second", "3 third"}, slices.Collect(seq))
+}
+
+func TestFilterOkSeq(t *testing.T) {
+	errOdd := errors.New("odd")
+	source := func(yield func(int, error) bool) {
+		for i := range 5 {
+			var err error
+			if i%2 == 1 {
+				err = errOdd
+			}
+
+			if !yield(i, err) {
+				return
+			}
+		}
+	}
+
+	t.Run("collect errors", func(t *testing.T) {
+		var errs []error
+		seq := slice_utils.FilterOkSeq(source, func(err error) bool {
+			errs = append(errs, err)
+			return true
+		})
+		assert.Equal(t, []int{0, 2, 4}, slices.Collect(seq))
+		assert.Equal(t, []error{errOdd, errOdd}, errs)
+	})
+
+	t.Run("stop on error", func(t *testing.T) {
+		seq := slice_utils.FilterOkSeq(source, func(err error) bool { return false })
+		assert.Equal(t, []int{0}, slices.Collect(seq))
+	})
+
+	t.Run("nil handler", func(t *testing.T) {
+		seq := slice_utils.FilterOkSeq(source, nil)
+		assert.Equal(t, []int{0}, slices.Collect(seq))
+	})
+
+	t.Run("LinesSeq", func(t *testing.T) {
+		seq := slice_utils.FilterOkSeq(slice_utils.LinesSeq(strings.NewReader("a\nb")), nil)
+		assert.Equal(t, []string{"a", "b"}, slices.Collect(seq))
+	})
+}
+
+func TestCountFuncSeq(t *testing.T) {
+	data := slices.Values([]int{1, 2, 3, 4, 5})
+	assert.Equal(t, 2, slice_utils.CountFuncSeq(data, func(v int) bool { return v%2 == 0 }))
+	assert.Equal(t, 0, slice_utils.CountFuncSeq(data, func(v int) bool { return v > 5 }))
+	assert.Equal(t, 0, slice_utils.CountFuncSeq(slices.Values([]int{}), func(v int) bool { return true }))
+}
+
+func TestGroupPairsSeq(t *testing.T) {
+	data := []int{1, 2, 3, 4, 5}
+	seq := slice_utils.GroupPairsSeq(slices.Values(data), func(v int) string {
+		if v%2 == 0 {
+			return "even"
+		}
+		return "odd"
+	})
+	assert.Equal(t, map[string][]int{"even": {2, 4}, "odd": {1, 3, 5}}, maps.Collect(seq))
+}
+
+func TestGroupPairsSortedSeq(t *testing.T) {
+	data := []string{"bb", "a", "ccc", "dd", "e"}
+	seq := slice_utils.GroupPairsSortedSeq(slices.Values(data), func(v string) int { return len(v) })
+
+	var keys []int
+	var groups [][]string
+	for k, g := range seq {
+		keys = append(keys, k)
+		groups = append(groups, g)
+	}
+
+	assert.Equal(t, []int{1, 2, 3}, keys)
+	assert.Equal(t, [][]string{{"a", "e"}, {"bb", "dd"}, {"ccc"}}, groups)
+}
+
+func TestReplaceOrFuncSeq(t *testing.T) {
+	data := []string{"Foo", "NYC", "Bar"}
+	overrides := map[string]string{"NYC": "New York"}
+	seq := slice_utils.ReplaceOrFuncSeq(slices.Values(data), overrides, strings.ToLower)
+	assert.Equal(t, []string{"foo", "New York", "bar"}, slices.Collect(seq))
+}
+
+func TestSumCheckedSeq(t *testing.T) {
+	t.Run("sum", func(t *testing.T) {
+		got, err := slice_utils.SumCheckedSeq(slices.Values([]int64{100, -50, 25}))
+		assert.NoError(t, err)
+		assert.Equal(t, int64(75), got)
+	})
+
+	t.Run("positive overflow", func(t *testing.T) {
+		_, err := slice_utils.SumCheckedSeq(slices.Values([]int64{math.MaxInt64 - 1, 1, 1}))
+		assert.ErrorIs(t, err, slice_utils.ErrOverflow)
+	})
+
+	t.Run("negative overflow", func(t *testing.T) {
+		_, err := slice_utils.SumCheckedSeq(slices.Values([]int8{-100, -29}))
+		assert.ErrorIs(t, err, slice_utils.ErrOverflow)
+	})
+
+	t.Run("boundaries", func(t *testing.T) {
+		got, err := slice_utils.SumCheckedSeq(slices.Values([]int8{-100, -28, 127, 127}))
+		assert.NoError(t, err)
+		assert.Equal(t, int8(126), got)
+	})
+
+	t.Run("unsigned overflow", func(t *testing.T) {
+		_, err := slice_utils.SumCheckedSeq(slices.Values([]uint8{200, 56}))
+		assert.ErrorIs(t, err, slice_utils.ErrOverflow)
+
+		got, err := slice_utils.SumCheckedSeq(slices.Values([]uint8{200, 55}))
+		assert.NoError(t, err)
+		assert.Equal(t, uint8(255), got)
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		got, err := slice_utils.SumCheckedSeq(slices.Values([]int{}))
+		assert.NoError(t, err)
+		assert.Equal(t, 0, got)
+	})
+}
+
+func TestCumSumSeq(t *testing.T) {
+	seq := slice_utils.CumSumSeq(slices.Values([]int{4, 0, 1}))
+	assert.Equal(t, []int{4, 4, 5}, slices.Collect(seq))
+	assert.Equal(t, []int{4, 4, 5}, slices.Collect(seq))
+}
+
+func TestFilterTakeSeq(t *testing.T) {
+	seq := slice_utils.FilterTakeSeq(slices.Values([]int{1, 2, 3, 4, 5, 6}), 2, func(v int) bool { return v%2 == 1 })
+	assert.Equal(t, []int{1, 3}, slices.Collect(seq))
+}
+
+func TestStringsSeq(t *testing.T) {
+	seq := slice_utils.StringsSeq(slices.Values([]MyStringer{1, 2}))
+	assert.Equal(t, []string{"val1", "val2"}, slices.Collect(seq))
+}
+
+func TestHasPrefixSeq(t *testing.T) {
+	pulled := 0
+	data := slice_utils.ReplaceFuncSeq(slices.Values([]byte("HEADERpayload")), func(v byte) byte {
+		pulled++
+		return v
+	})
+
+	assert.True(t, slice_utils.HasPrefixSeq(data, []byte("HEAD")))
+	assert.Equal(t, 4, pulled)
+
+	assert.False(t, slice_utils.HasPrefixSeq(data, []byte("HEAP")))
+	assert.True(t, slice_utils.HasPrefixSeq(data, []byte{}))
+	assert.False(t, slice_utils.HasPrefixSeq(slices.Values([]byte("HE")), []byte("HEAD")))
+	assert.True(t, slice_utils.HasPrefixSeq(slices.Values([]int{}), nil))
+}
+
+func TestDeduplicateWindowSeq(t *testing.T) {
+	t.Run("within window", func(t *testing.T) {
+		seq := slice_utils.DeduplicateWindowSeq(slices.Values([]int{1, 2, 1, 3, 4, 1, 2}), 2)
+		assert.Equal(t, []int{1, 2, 3, 4, 1, 2}, slices.Collect(seq))
+	})
+
+	t.Run("repeat refreshes", func(t *testing.T) {
+		seq := slice_utils.DeduplicateWindowSeq(slices.Values([]int{1, 2, 1, 3, 1, 2}), 2)
+		assert.Equal(t, []int{1, 2, 3, 2}, slices.Collect(seq))
+	})
+
+	t.Run("large window", func(t *testing.T) {
+		data := []int{1, 2, 1, 3, 2}
+		seq := slice_utils.DeduplicateWindowSeq(slices.Values(data), 10)
+		assert.Equal(t, slices.Collect(slice_utils.DeduplicationSeq(slices.Values(data))), slices.Collect(seq))
+	})
+
+	t.Run("no window", func(t *testing.T) {
+		seq := slice_utils.DeduplicateWindowSeq(slices.Values([]int{1, 1}), 0)
+		assert.Equal(t, []int{1, 1}, slices.Collect(seq))
+	})
+}
+
+func TestMovingAverageSeq(t *testing.T) {
+	seq := slice_utils.MovingAverageSeq(slices.Values([]int{1, 2, 3, 4, 5, 6}), 3)
+	assert.Equal(t, []float64{2, 3, 4, 5}, slices.Collect(seq))
+
+	seq = slice_utils.MovingAverageSeq(slices.Values([]int{1, 2}), 3)
+	assert.Empty(t, slices.Collect(seq))
+
+	seq = slice_utils.MovingAverageSeq(slices.Values([]int{1, 2}), 1)
+	assert.Equal(t, []float64{1, 2}, slices.Collect(seq))
+
+	seq = slice_utils.MovingAverageSeq(slices.Values([]int{1, 2}), 0)
+	assert.Empty(t, slices.Collect(seq))
+
+	spike := slice_utils.MovingAverageSeq(slices.Values([]float64{1e17, 1, 1, 1, 1, 1}), 2)
+	assert.Equal(t, []float64{5e16, 1, 1, 1, 1}, slices.Collect(spike))
+}
+
+func TestPipe(t *testing.T) {
+	seq := slice_utils.Pipe(slices.Values([]int{1, 1, 2, 3, 3, 4, 5, 6}),
+		slice_utils.CompactSeq[int],
+		func(s iter.Seq[int]) iter.Seq[int] {
+			return slice_utils.FilterSeq(s, func(v int) bool { return v%2 == 1 })
+		},
+		func(s iter.Seq[int]) iter.Seq[int] {
+			return slice_utils.ReplaceSeq(s, map[int]int{5: 50})
+		},
+	)
+	assert.Equal(t, []int{1, 3, 50}, slices.Collect(seq))
+
+	assert.Equal(t, []int{1, 2}, slices.Collect(slice_utils.Pipe(slices.Values([]int{1, 2}))))
+}
+
+func TestTapSeq(t *testing.T) {
+	var seen []int
+	seq := slice_utils.TapSeq(slices.Values([]int{1, 2, 3}), func(v int) {
+		seen = append(seen, v)
+	})
+	assert.Equal(t, []int{1, 2, 3}, slices.Collect(seq))
+	assert.Equal(t, []int{1, 2, 3}, seen)
+}
+
+func TestMaterialize(t *testing.T) {
+	got, n := slice_utils.Materialize(slices.Values([]string{"a", "b"}))
+	assert.Equal(t, []string{"a", "b"}, got)
+	assert.Equal(t, 2, n)
+
+	got, n = slice_utils.Materialize(slices.Values([]string{}))
+	assert.NotNil(t, got)
+	assert.Equal(t, 0, n)
+}
+
+func TestDrain(t *testing.T) {
+	sum := 0
+	n := slice_utils.Drain(slice_utils.TapSeq(slices.Values([]int{1, 2, 3}), func(v int) {
+		sum += v
+	}))
+	assert.Equal(t, 3, n)
+	assert.Equal(t, 6, sum)
+}
+
+func TestDistinctUntilChangedSeq(t *testing.T) {
+	seq := slice_utils.DistinctUntilChangedSeq(slices.Values([]string{"on", "on", "off", "on", "on"}))
+	assert.Equal(t, []string{"on", "off", "on"}, slices.Collect(seq))
+}
+
+func TestDistinctUntilChangedFuncSeq(t *testing.T) {
+	type state struct {
+		Values []int
+	}
+
+	data := []state{{[]int{1}}, {[]int{1}}, {[]int{1, 2}}, {[]int{1}}}
+	seq := slice_utils.DistinctUntilChangedFuncSeq(slices.Values(data), func(a, b state) bool {
+		return slices.Equal(a.Values, b.Values)
+	})
+	assert.Equal(t, []state{{[]int{1}}, {[]int{1, 2}}, {[]int{1}}}, slices.Collect(seq))
+
+	near := slice_utils.DistinctUntilChangedFuncSeq(slices.Values([]float64{1, 1.05, 1.1, 1.2}), func(a, b float64) bool {
+		return math.Abs(a-b) < 0.15
+	})
+	assert.Equal(t, []float64{1, 1.2}, slices.Collect(near))
+}
+
+func TestScanErrSeq(t *testing.T) {
+	seq := slice_utils.ScanErrSeq(slices.Values([]string{"1", "x", "3"}), 0, func(acc int, v string) (int, error) {
+		i, err := strconv.Atoi(v)
+		if err != nil {
+			return 0, err
+		}
+		return acc + i, nil
+	})
+
+	var accs []int
+	var errs int
+	for acc, err := range seq {
+		accs = append(accs, acc)
+		if err != nil {
+			errs++
+		}
+	}
+
+	assert.Equal(t, []int{1, 1, 4}, accs)
+	assert.Equal(t, 1, errs)
+
+	var stopped []int
+	for acc, err := range seq {
+		if err != nil {
+			break
+		}
+		stopped = append(stopped, acc)
+	}
+	assert.Equal(t, []int{1}, stopped)
+}
+
+func TestGroupSeqCapped(t *testing.T) {
+	collect := func(seq iter.Seq2[string, []int]) ([]string, [][]int) {
+		var keys []string
+		var groups [][]int
+		for k, g := range seq {
+			keys = append(keys, k)
+			groups = append(groups, g)
+		}
+		return keys, groups
+	}
+
+	key := func(v int) string { return string(rune('a' + v/10)) }
+
+	t.Run("evict oldest", func(t *testing.T) {
+		seq := slice_utils.GroupSeqCapped(slices.Values([]int{1, 11, 2, 21, 12, 3}), key, 2)
+		keys, groups := collect(seq)
+		assert.Equal(t, []string{"a", "b", "c", "a"}, keys)
+		assert.Equal(t, [][]int{{1, 2}, {11, 12}, {21}, {3}}, groups)
+	})
+
+	t.Run("within limit", func(t *testing.T) {
+		seq := slice_utils.GroupSeqCapped(slices.Values([]int{1, 11, 2}), key, 5)
+		keys, groups := collect(seq)
+		assert.Equal(t, []string{"a", "b"}, keys)
+		assert.Equal(t, [][]int{{1, 2}, {11}}, groups)
+	})
+
+	t.Run("limit below one", func(t *testing.T) {
+		seq := slice_utils.GroupSeqCapped(slices.Values([]int{1, 2, 11}), key, 0)
+		keys, groups := collect(seq)
+		assert.Equal(t, []string{"a", "b"}, keys)
+		assert.Equal(t, [][]int{{1, 2}, {11}}, groups)
+	})
+}
+
+func TestJoinSeq2(t *testing.T) {
+	users := maps.All(map[int]string{1: "alice", 2: "bob", 3: "carol"})
+	orders := slices.Values([]slice_utils.Entry[int, float64]{{Key: 1, Value: 9.5}, {Key: 3, Value: 2}, {Key: 1, Value: 1}, {Key: 4, Value: 7}})
+	right := func(yield func(int, float64) bool) {
+		for o := range orders {
+			if !yield(o.Key, o.Value) {
+				return
+			}
+		}
+	}
+
+	type row struct {
+		ID    int
+		Name  string
+		Total float64
+	}
+
+	var got []row
+	for k, p := range slice_utils.JoinSeq2(users, right) {
+		got = append(got, row{k, p.L, p.R})
+	}
+
+	assert.ElementsMatch(t, []row{{1, "alice", 9.5}, {1, "alice", 1}, {3, "carol", 2}}, got)
+}
+
+func TestSumFuncSeqAll(t *testing.T) {
+	parse := func(v string) (int, error) {
+		return strconv.Atoi(v)
+	}
+
+	got, errs := slice_utils.SumFuncSeqAll(slices.Values([]string{"1", "x", "2", "y", "3"}), parse)
+	assert.Equal(t, 6, got)
+	assert.Len(t, errs, 2)
+
+	got, errs = slice_utils.SumFuncSeqAll(slices.Values([]string{"1", "2"}), parse)
+	assert.Equal(t, 3, got)
+	assert.Empty(t, errs)
+}
+
+func TestConvertWhileSeq(t *testing.T) {
+	pulled := 0
+	tokens := slice_utils.TapSeq(slices.Values([]string{"a", "b", "END", "c"}), func(string) { pulled++ })
+	seq := slice_utils.ConvertWhileSeq(tokens, func(v string) (string, bool) {
+		return strings.ToUpper(v), v != "END"
+	})
+	assert.Equal(t, []string{"A", "B"}, slices.Collect(seq))
+	assert.Equal(t, 3, pulled)
+}
+
+func TestMarkDuplicatesSeq(t *testing.T) {
+	var values []int
+	var marks []bool
+	for v, dup := range slice_utils.MarkDuplicatesSeq(slices.Values([]int{1, 2, 1, 3, 2, 1})) {
+		values = append(values, v)
+		marks = append(marks, dup)
+	}
+
+	assert.Equal(t, []int{1, 2, 1, 3, 2, 1}, values)
+	assert.Equal(t, []bool{false, false, true, false, true, true}, marks)
+}
+
+func TestReplaceWhereSeq(t *testing.T) {
+	seq := slice_utils.ReplaceWhereSeq(slices.Values([]int{-2, 3, -1, 0, 5}), func(v int) bool { return v < 0 }, 0)
+	assert.Equal(t, []int{0, 3, 0, 0, 5}, slices.Collect(seq))
+
+	words := slice_utils.ReplaceWhereSeq(slices.Values([][]string{{"a"}, nil, {"b"}}), func(v []string) bool { return v == nil }, []string{})
+	assert.Equal(t, [][]string{{"a"}, {}, {"b"}}, slices.Collect(words))
+}
+
+func TestTopFrequentSeq(t *testing.T) {
+	data := slices.Values([]string{"404", "500", "404", "200", "500", "404", "302", "200"})
+
+	got := slice_utils.TopFrequentSeq(data, 3)
+	assert.Equal(t, []slice_utils.ValueCount[string]{{"404", 3}, {"500", 2}, {"200", 2}}, got)
+
+	got = slice_utils.TopFrequentSeq(data, 10)
+	assert.Len(t, got, 4)
+	assert.Equal(t, slice_utils.ValueCount[string]{"302", 1}, got[3])
+
+	got = slice_utils.TopFrequentSeq(data, 0)
+	assert.NotNil(t, got)
+	assert.Empty(t, got)
+}
+
+func TestBatchTimeSeq(t *testing.T) {
+	t.Run("size", func(t *testing.T) {
+		seq := slice_utils.BatchTimeSeq(slices.Values([]int{1, 2, 3, 4, 5}), 2, time.Hour)
+		assert.Equal(t, [][]int{{1, 2}, {3, 4}, {5}}, slices.Collect(seq))
+	})
+
+	t.Run("time", func(t *testing.T) {
+		slow := slice_utils.TapSeq(slices.Values([]int{1, 2, 3, 4}), func(v int) {
+			if v == 3 {
+				time.Sleep(20 * time.Millisecond)
+			}
+		})
+		seq := slice_utils.BatchTimeSeq(slow, 10, 10*time.Millisecond)
+		assert.Equal(t, [][]int{{1, 2, 3}, {4}}, slices.Collect(seq))
+	})
+
+	t.Run("no limits", func(t *testing.T) {
+		seq := slice_utils.BatchTimeSeq(slices.Values([]int{1, 2, 3}), 0, 0)
+		assert.Equal(t, [][]int{{1, 2, 3}}, slices.Collect(seq))
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		seq := slice_utils.BatchTimeSeq(slices.Values([]int{}), 2, time.Second)
+		assert.Empty(t, slices.Collect(seq))
+	})
+}
+
+func TestDropLastSeq(t *testing.T) {
+	data := []int{1, 2, 3, 4, 5}
+	assert.Equal(t, []int{1, 2, 3}, slices.Collect(slice_utils.DropLastSeq(slices.Values(data), 2)))
+	assert.Equal(t, data, slices.Collect(slice_utils.DropLastSeq(slices.Values(data), 0)))
+	assert.Empty(t, slices.Collect(slice_utils.DropLastSeq(slices.Values(data), 5)))
+	assert.Empty(t, slices.Collect(slice_utils.DropLastSeq(slices.Values(data), 10)))
+}
+
+func TestChunkMapSeq(t *testing.T) {
+	seq := slice_utils.ChunkMapSeq(slices.Values([]string{"a", "b", "c", "d", "e"}), 2, func(chunk []string) string {
+		return strings.Join(chunk, "")
+	})
+	assert.Equal(t, []string{"ab", "cd", "e"}, slices.Collect(seq))
+
+	seq = slice_utils.ChunkMapSeq(slices.Values([]string{"a", "b"}), 0, func(chunk []string) string {
+		return strings.Join(chunk, "")
+	})
+	assert.Equal(t, []string{"ab"}, slices.Collect(seq))
+}
+
+func TestJoinSeq(t *testing.T) {
+	got := slice_utils.JoinSeq(slices.Values([]int{1, 2, 3}), ", ", strconv.Itoa)
+	assert.Equal(t, "1, 2, 3", got)
+
+	assert.Equal(t, "", slice_utils.JoinSeq(slices.Values([]int{}), ", ", strconv.Itoa))
+	assert.Equal(t, "1", slice_utils.JoinSeq(slices.Values([]int{1}), ", ", strconv.Itoa))
+}
+
+func TestJoinStringerSeq(t *testing.T) {
+	got := slice_utils.JoinStringerSeq(slices.Values([]MyStringer{1, 2}), "|")
+	assert.Equal(t, "val1|val2", got)
+}
+
+func TestSlidingChunkSeq(t *testing.T) {
+	data := []int{1, 2, 3, 4, 5, 6}
+
+	tests := []struct {
+		name string
+		size int
+		step int
+		want [][]int
+	}{
+		{
+			name: "overlapping",
+			size: 3,
+			step: 2,
+			want: [][]int{{1, 2, 3}, {3, 4, 5}},
+		},
+		{
+			name: "step one",
+			size: 4,
+			step: 1,
+			want: [][]int{{1, 2, 3, 4}, {2, 3, 4, 5}, {3, 4, 5, 6}},
+		},
+		{
+			name: "disjoint",
+			size: 2,
+			step: 2,
+			want: [][]int{{1, 2}, {3, 4}, {5, 6}},
+		},
+		{
+			name: "gaps",
+			size: 2,
+			step: 3,
+			want: [][]int{{1, 2}, {4, 5}},
+		},
+		{
+			name: "step zero",
+			size: 5,
+			step: 0,
+			want: [][]int{{1, 2, 3, 4, 5}, {2, 3, 4, 5, 6}},
+		},
+		{
+			name: "window larger than input",
+			size: 7,
+			step: 1,
+			want: nil,
+		},
+		{
+			name: "size zero",
+			size: 0,
+			step: 1,
+			want: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := slices.Collect(slice_utils.SlidingChunkSeq(slices.Values(data), tt.size, tt.step))
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestPrependSeq(t *testing.T) {
+	got := slices.Collect(slice_utils.PrependSeq(slices.Values([]string{"a", "b"}), "header"))
+	assert.Equal(t, []string{"header", "a", "b"}, got)
+
+	got = slices.Collect(slice_utils.PrependSeq(slices.Values([]string{"a"})))
+	assert.Equal(t, []string{"a"}, got)
+}
+
+func TestAppendSeq(t *testing.T) {
+	got := slices.Collect(slice_utils.AppendSeq(slices.Values([]string{"a", "b"}), "footer", "end"))
+	assert.Equal(t, []string{"a", "b", "footer", "end"}, got)
+
+	got = slices.Collect(slice_utils.AppendSeq(slices.Values([]string{}), "footer"))
+	assert.Equal(t, []string{"footer"}, got)
+}
+
+// countedAll is like slices.All but counts the pairs pulled from it.
+func countedAll(data []int, pulled *int) iter.Seq2[int, int] {
+	return func(yield func(int, int) bool) {
+		for i, v := range data {
+			*pulled++
+			if !yield(i, v) {
+				return
+			}
+		}
+	}
+}
+
 func TestEarlyTermination(t *testing.T) {
 	t.Run("FilterSeq", func(t *testing.T) {
 		data := []int{1, 2, 3, 4, 5}
@@ -225,10 +1414,251 @@ func TestEarlyTermination(t *testing.T) {
 		assert.Equal(t, 1, count)
 	})
 
-	t.Run("RemoveSeq", func(t *testing.T) {
+	t.Run("RemoveSeq", func(t *testing.T) {
+		data := []int{1, 2, 3}
+		remove := []int{}
+		seq := slice_utils.RemoveSeq(slices.Values(data), slices.Values(remove))
+		count := 0
+		seq(func(v int) bool {
+			count++
+			return false
+		})
+		assert.Equal(t, 1, count)
+	})
+
+	t.Run("PatternSeq", func(t *testing.T) {
+		data := []string{"a", "b", "c"}
+		re := regexp.MustCompile(".")
+		seq := slice_utils.PatternSeq(slices.Values(data), re)
+		count := 0
+		seq(func(v string) bool {
+			count++
+			return false
+		})
+		assert.Equal(t, 1, count)
+	})
+
+	t.Run("StringPatternSeq", func(t *testing.T) {
+		data := []string{"a", "a", "a"}
+		seq := slice_utils.StringPatternSeq(slices.Values(data), "a")
+		count := 0
+		seq(func(v string) bool {
+			count++
+			return false
+		})
+		assert.Equal(t, 1, count)
+	})
+
+	t.Run("DuplicateSeq", func(t *testing.T) {
+		data := []int{1, 1, 2, 2}
+		seq := slice_utils.DuplicateSeq(slices.Values(data))
+		count := 0
+		seq(func(v int) bool {
+			count++
+			return false
+		})
+		assert.Equal(t, 1, count)
+	})
+
+	t.Run("DeduplicationSeq", func(t *testing.T) {
+		data := []int{1, 2, 3}
+		seq := slice_utils.DeduplicationSeq(slices.Values(data))
+		count := 0
+		seq(func(v int) bool {
+			count++
+			return false
+		})
+		assert.Equal(t, 1, count)
+	})
+
+	t.Run("HashSeq", func(t *testing.T) {
+		data := []int{1, 2, 3}
+		seq := slice_utils.HashSeq(slices.Values(data))
+		count := 0
+		seq(func(h uint64, v int) bool {
+			count++
+			return false
+		})
+		assert.Equal(t, 1, count)
+	})
+
+	t.Run("GroupSeq", func(t *testing.T) {
+		data := []int{1, 2}
+		seq := slice_utils.GroupSeq[[]int](slices.Values(data), func(v int) int { return v })
+		count := 0
+		seq(func(g []int) bool {
+			count++
+			return false
+		})
+		assert.Equal(t, 1, count)
+	})
+
+	t.Run("ReplaceFuncSeq", func(t *testing.T) {
+		data := []int{1, 2, 3}
+		seq := slice_utils.ReplaceFuncSeq(slices.Values(data), func(v int) int { return v })
+		count := 0
+		seq(func(v int) bool {
+			count++
+			return false
+		})
+		assert.Equal(t, 1, count)
+	})
+
+	t.Run("ReplaceSeq", func(t *testing.T) {
+		data := []int{1, 2, 3}
+		seq := slice_utils.ReplaceSeq(slices.Values(data), map[int]int{})
+		count := 0
+		seq(func(v int) bool {
+			count++
+			return false
+		})
+		assert.Equal(t, 1, count)
+	})
+
+	t.Run("ReplaceSeq_Match", func(t *testing.T) {
+		data := []int{1, 2, 3}
+		seq := slice_utils.ReplaceSeq(slices.Values(data), map[int]int{1: 10})
+		count := 0
+		seq(func(v int) bool {
+			count++
+			return false
+		})
+		assert.Equal(t, 1, count)
+	})
+
+	t.Run("ConvertSeq", func(t *testing.T) {
+		data := []int{1, 2, 3}
+		seq := slice_utils.ConvertSeq(slices.Values(data), func(v int) int { return v })
+		count := 0
+		seq(func(v int) bool {
+			count++
+			return false
+		})
+		assert.Equal(t, 1, count)
+	})
+
+	t.Run("AnySeq", func(t *testing.T) {
+		data := []int{1, 2, 3}
+		seq := slice_utils.AnySeq(slices.Values(data))
+		count := 0
+		seq(func(v any) bool {
+			count++
+			return false
+		})
+		assert.Equal(t, 1, count)
+	})
+
+	t.Run("FilterIndexSeq", func(t *testing.T) {
+		data := []int{1, 2, 3}
+		seq := slice_utils.FilterIndexSeq(slices.Values(data), func(i int, v int) bool { return true })
+		count := 0
+		seq(func(v int) bool {
+			count++
+			return false
+		})
+		assert.Equal(t, 1, count)
+	})
+
+	t.Run("RejectSeq", func(t *testing.T) {
+		data := []int{1, 2, 3}
+		seq := slice_utils.RejectSeq(slices.Values(data), func(v int) bool { return false })
+		count := 0
+		seq(func(v int) bool {
+			count++
+			return false
+		})
+		assert.Equal(t, 1, count)
+	})
+
+	t.Run("LinesSeq", func(t *testing.T) {
+		seq := slice_utils.LinesSeq(strings.NewReader("a\nb\nc"))
+		count := 0
+		seq(func(v string, err error) bool {
+			count++
+			return false
+		})
+		assert.Equal(t, 1, count)
+	})
+
+	t.Run("ClampSeq", func(t *testing.T) {
+		data := []int{1, 2, 3}
+		seq := slice_utils.ClampSeq(slices.Values(data), 0, 2)
+		count := 0
+		seq(func(v int) bool {
+			count++
+			return false
+		})
+		assert.Equal(t, 1, count)
+	})
+
+	t.Run("RunningMaxSeq", func(t *testing.T) {
+		data := []int{1, 2, 3}
+		seq := slice_utils.RunningMaxSeq(slices.Values(data))
+		count := 0
+		seq(func(v int) bool {
+			count++
+			return false
+		})
+		assert.Equal(t, 1, count)
+	})
+
+	t.Run("RunningMinSeq", func(t *testing.T) {
+		data := []int{1, 2, 3}
+		seq := slice_utils.RunningMinSeq(slices.Values(data))
+		count := 0
+		seq(func(v int) bool {
+			count++
+			return false
+		})
+		assert.Equal(t, 1, count)
+	})
+
+	t.Run("SortSeq", func(t *testing.T) {
+		data := []int{3, 2, 1}
+		seq := slice_utils.SortSeq(slices.Values(data))
+		count := 0
+		seq(func(v int) bool {
+			count++
+			return false
+		})
+		assert.Equal(t, 1, count)
+	})
+
+	t.Run("SortFuncSeq", func(t *testing.T) {
+		data := []int{3, 2, 1}
+		seq := slice_utils.SortFuncSeq(slices.Values(data), func(a, b int) bool { return a < b })
+		count := 0
+		seq(func(v int) bool {
+			count++
+			return false
+		})
+		assert.Equal(t, 1, count)
+	})
+
+	t.Run("KWayMergeSeq", func(t *testing.T) {
+		seq := slice_utils.KWayMergeSeq(slices.Values([]int{1, 3}), slices.Values([]int{2, 4}))
+		count := 0
+		seq(func(v int) bool {
+			count++
+			return false
+		})
+		assert.Equal(t, 1, count)
+	})
+
+	t.Run("MemoizeSeq", func(t *testing.T) {
+		data := []int{1, 2, 3}
+		seq := slice_utils.MemoizeSeq(slices.Values(data), func(v int) int { return v })
+		count := 0
+		seq(func(v int) bool {
+			count++
+			return false
+		})
+		assert.Equal(t, 1, count)
+	})
+
+	t.Run("LimitSeq", func(t *testing.T) {
 		data := []int{1, 2, 3}
-		remove := []int{}
-		seq := slice_utils.RemoveSeq(slices.Values(data), slices.Values(remove))
+		seq := slice_utils.LimitSeq(slices.Values(data), 2)
 		count := 0
 		seq(func(v int) bool {
 			count++
@@ -237,10 +1667,215 @@ func TestEarlyTermination(t *testing.T) {
 		assert.Equal(t, 1, count)
 	})
 
-	t.Run("PatternSeq", func(t *testing.T) {
-		data := []string{"a", "b", "c"}
-		re := regexp.MustCompile(".")
-		seq := slice_utils.PatternSeq(slices.Values(data), re)
+	t.Run("WithDeadlineSeq", func(t *testing.T) {
+		data := []int{1, 2, 3}
+		seq := slice_utils.WithDeadlineSeq(slices.Values(data), time.Hour)
+		count := 0
+		seq(func(v int) bool {
+			count++
+			return false
+		})
+		assert.Equal(t, 1, count)
+	})
+
+	t.Run("DistinctSeq", func(t *testing.T) {
+		data := []int{1, 2, 3}
+		seq := slice_utils.DistinctSeq(slices.Values(data), func(v int) int { return v })
+		count := 0
+		seq(func(v int) bool {
+			count++
+			return false
+		})
+		assert.Equal(t, 1, count)
+	})
+
+	t.Run("Cache", func(t *testing.T) {
+		data := []int{1, 2, 3}
+		seq := slice_utils.Cache(slices.Values(data))
+		count := 0
+		seq(func(v int) bool {
+			count++
+			return false
+		})
+		assert.Equal(t, 1, count)
+	})
+
+	t.Run("FilterSeq2", func(t *testing.T) {
+		pulled := 0
+		seq := slice_utils.FilterSeq2(countedAll([]int{1, 2, 3, 4, 5, 6}, &pulled), func(i int, v int) bool { return v%2 == 0 })
+		var got []int
+		seq(func(i int, v int) bool {
+			got = append(got, i)
+			return len(got) < 2
+		})
+		assert.Equal(t, []int{1, 3}, got)
+		assert.Equal(t, 4, pulled)
+	})
+
+	t.Run("MapSeq2", func(t *testing.T) {
+		pulled := 0
+		seq := slice_utils.MapSeq2(countedAll([]int{1, 2, 3, 4}, &pulled), func(i int, v int) (int, int) { return v, i })
+		var got []int
+		seq(func(k int, v int) bool {
+			got = append(got, k)
+			return len(got) < 2
+		})
+		assert.Equal(t, []int{1, 2}, got)
+		assert.Equal(t, 2, pulled)
+	})
+
+	t.Run("KeysOf", func(t *testing.T) {
+		pulled := 0
+		seq := slice_utils.KeysOf(countedAll([]int{1, 2, 3, 4}, &pulled))
+		var got []int
+		seq(func(v int) bool {
+			got = append(got, v)
+			return len(got) < 2
+		})
+		assert.Equal(t, []int{0, 1}, got)
+		assert.Equal(t, 2, pulled)
+	})
+
+	t.Run("ValuesOf", func(t *testing.T) {
+		pulled := 0
+		seq := slice_utils.ValuesOf(countedAll([]int{1, 2, 3, 4}, &pulled))
+		var got []int
+		seq(func(v int) bool {
+			got = append(got, v)
+			return len(got) < 2
+		})
+		assert.Equal(t, []int{1, 2}, got)
+		assert.Equal(t, 2, pulled)
+	})
+
+	t.Run("FilterMapSeq", func(t *testing.T) {
+		data := []int{1, 2, 3}
+		seq := slice_utils.FilterMapSeq(slices.Values(data), func(v int) (int, bool) { return v, true })
+		count := 0
+		seq(func(v int) bool {
+			count++
+			return false
+		})
+		assert.Equal(t, 1, count)
+	})
+
+	t.Run("MapSeq", func(t *testing.T) {
+		data := []int{1, 2, 3}
+		seq := slice_utils.MapSeq(slices.Values(data), func(v int) int { return v })
+		count := 0
+		seq(func(v int) bool {
+			count++
+			return false
+		})
+		assert.Equal(t, 1, count)
+	})
+
+	t.Run("ZipWithSeq", func(t *testing.T) {
+		data := []int{1, 2, 3}
+		seq := slice_utils.ZipWithSeq(slices.Values(data), slices.Values(data), func(a, b int) int { return a + b })
+		count := 0
+		seq(func(v int) bool {
+			count++
+			return false
+		})
+		assert.Equal(t, 1, count)
+	})
+
+	t.Run("ReverseSeq", func(t *testing.T) {
+		data := []int{1, 2, 3}
+		seq := slice_utils.ReverseSeq(slices.Values(data))
+		count := 0
+		seq(func(v int) bool {
+			count++
+			return false
+		})
+		assert.Equal(t, 1, count)
+	})
+
+	t.Run("ReverseSeq2", func(t *testing.T) {
+		pulled := 0
+		seq := slice_utils.ReverseSeq2(countedAll([]int{1, 2, 3, 4}, &pulled))
+		var got []int
+		seq(func(i int, v int) bool {
+			got = append(got, i)
+			return len(got) < 2
+		})
+		assert.Equal(t, []int{3, 2}, got)
+		assert.Equal(t, 4, pulled)
+	})
+
+	t.Run("IntersperseSeq", func(t *testing.T) {
+		data := []int{1, 2, 3}
+		seq := slice_utils.IntersperseSeq(slices.Values(data), 0)
+		count := 0
+		seq(func(v int) bool {
+			count++
+			return count < 2
+		})
+		assert.Equal(t, 2, count)
+	})
+
+	t.Run("GroupConsecutiveSeq", func(t *testing.T) {
+		data := []int{1, 2, 3}
+		seq := slice_utils.GroupConsecutiveSeq(slices.Values(data), func(prev, curr int) bool { return false })
+		count := 0
+		seq(func(v []int) bool {
+			count++
+			return false
+		})
+		assert.Equal(t, 1, count)
+	})
+
+	t.Run("DeduplicateCountSeq", func(t *testing.T) {
+		data := []int{1, 2, 3}
+		seq := slice_utils.DeduplicateCountSeq(slices.Values(data))
+		count := 0
+		seq(func(v int, c int) bool {
+			count++
+			return false
+		})
+		assert.Equal(t, 1, count)
+	})
+
+	t.Run("ChunkReduceSeq", func(t *testing.T) {
+		data := []int{1, 2, 3}
+		seq := slice_utils.ChunkReduceSeq(slices.Values(data), 1, 0, func(acc int, val int) int { return acc + val })
+		count := 0
+		seq(func(v int) bool {
+			count++
+			return false
+		})
+		assert.Equal(t, 1, count)
+	})
+
+	t.Run("CompactSeq", func(t *testing.T) {
+		pulled := 0
+		src := slice_utils.TapSeq(slices.Values([]int{1, 1, 2, 2, 3, 3}), func(int) { pulled++ })
+		seq := slice_utils.CompactSeq(src)
+		var got []int
+		seq(func(v int) bool {
+			got = append(got, v)
+			return len(got) < 2
+		})
+		assert.Equal(t, []int{1, 2}, got)
+		assert.Equal(t, 3, pulled)
+	})
+
+	t.Run("ConvertIndexSeq", func(t *testing.T) {
+		pulled := 0
+		src := slice_utils.TapSeq(slices.Values([]int{10, 20, 30, 40}), func(int) { pulled++ })
+		seq := slice_utils.ConvertIndexSeq(src, func(i int, v int) int { return i + v })
+		var got []int
+		seq(func(v int) bool {
+			got = append(got, v)
+			return len(got) < 2
+		})
+		assert.Equal(t, []int{10, 21}, got)
+		assert.Equal(t, 2, pulled)
+	})
+
+	t.Run("FilterOkSeq", func(t *testing.T) {
+		seq := slice_utils.FilterOkSeq(slice_utils.LinesSeq(strings.NewReader("a\nb\nc")), nil)
 		count := 0
 		seq(func(v string) bool {
 			count++
@@ -249,9 +1884,64 @@ func TestEarlyTermination(t *testing.T) {
 		assert.Equal(t, 1, count)
 	})
 
-	t.Run("StringPatternSeq", func(t *testing.T) {
-		data := []string{"a", "a", "a"}
-		seq := slice_utils.StringPatternSeq(slices.Values(data), "a")
+	t.Run("GroupPairsSeq", func(t *testing.T) {
+		data := []int{1, 2}
+		seq := slice_utils.GroupPairsSeq(slices.Values(data), func(v int) int { return v })
+		count := 0
+		seq(func(k int, g []int) bool {
+			count++
+			return false
+		})
+		assert.Equal(t, 1, count)
+	})
+
+	t.Run("GroupPairsSortedSeq", func(t *testing.T) {
+		data := []int{1, 2}
+		seq := slice_utils.GroupPairsSortedSeq(slices.Values(data), func(v int) int { return v })
+		count := 0
+		seq(func(k int, g []int) bool {
+			count++
+			return false
+		})
+		assert.Equal(t, 1, count)
+	})
+
+	t.Run("ReplaceOrFuncSeq", func(t *testing.T) {
+		data := []int{1, 2, 3}
+		seq := slice_utils.ReplaceOrFuncSeq(slices.Values(data), map[int]int{1: 10}, func(v int) int { return v })
+		count := 0
+		seq(func(v int) bool {
+			count++
+			return false
+		})
+		assert.Equal(t, 1, count)
+	})
+
+	t.Run("CumSumSeq", func(t *testing.T) {
+		data := []int{1, 2, 3}
+		seq := slice_utils.CumSumSeq(slices.Values(data))
+		count := 0
+		seq(func(v int) bool {
+			count++
+			return false
+		})
+		assert.Equal(t, 1, count)
+	})
+
+	t.Run("FilterTakeSeq", func(t *testing.T) {
+		data := []int{1, 2, 3}
+		seq := slice_utils.FilterTakeSeq(slices.Values(data), 2, func(v int) bool { return true })
+		count := 0
+		seq(func(v int) bool {
+			count++
+			return false
+		})
+		assert.Equal(t, 1, count)
+	})
+
+	t.Run("StringsSeq", func(t *testing.T) {
+		data := []int{1, 2, 3}
+		seq := slice_utils.StringsSeq(slices.Values(data))
 		count := 0
 		seq(func(v string) bool {
 			count++
@@ -260,9 +1950,9 @@ func TestEarlyTermination(t *testing.T) {
 		assert.Equal(t, 1, count)
 	})
 
-	t.Run("DuplicateSeq", func(t *testing.T) {
-		data := []int{1, 1, 2, 2}
-		seq := slice_utils.DuplicateSeq(slices.Values(data))
+	t.Run("DeduplicateWindowSeq", func(t *testing.T) {
+		data := []int{1, 2, 3}
+		seq := slice_utils.DeduplicateWindowSeq(slices.Values(data), 2)
 		count := 0
 		seq(func(v int) bool {
 			count++
@@ -271,42 +1961,77 @@ func TestEarlyTermination(t *testing.T) {
 		assert.Equal(t, 1, count)
 	})
 
-	t.Run("DeduplicationSeq", func(t *testing.T) {
+	t.Run("MovingAverageSeq", func(t *testing.T) {
 		data := []int{1, 2, 3}
-		seq := slice_utils.DeduplicationSeq(slices.Values(data))
+		seq := slice_utils.MovingAverageSeq(slices.Values(data), 1)
+		count := 0
+		seq(func(v float64) bool {
+			count++
+			return false
+		})
+		assert.Equal(t, 1, count)
+	})
+
+	t.Run("TapSeq", func(t *testing.T) {
+		data := []int{1, 2, 3}
+		calls := 0
+		seq := slice_utils.TapSeq(slices.Values(data), func(v int) { calls++ })
 		count := 0
 		seq(func(v int) bool {
 			count++
 			return false
 		})
 		assert.Equal(t, 1, count)
+		assert.Equal(t, 1, calls)
 	})
 
-	t.Run("HashSeq", func(t *testing.T) {
+	t.Run("DistinctUntilChangedFuncSeq", func(t *testing.T) {
 		data := []int{1, 2, 3}
-		seq := slice_utils.HashSeq(slices.Values(data))
+		seq := slice_utils.DistinctUntilChangedFuncSeq(slices.Values(data), func(a, b int) bool { return a == b })
 		count := 0
-		seq(func(h uint64, v int) bool {
+		seq(func(v int) bool {
 			count++
 			return false
 		})
 		assert.Equal(t, 1, count)
 	})
 
-	t.Run("GroupSeq", func(t *testing.T) {
-		data := []int{1, 2}
-		seq := slice_utils.GroupSeq[[]int](slices.Values(data), func(v int) int { return v })
+	t.Run("ScanErrSeq", func(t *testing.T) {
+		data := []int{1, 2, 3}
+		seq := slice_utils.ScanErrSeq(slices.Values(data), 0, func(acc int, v int) (int, error) { return acc + v, nil })
 		count := 0
-		seq(func(g []int) bool {
+		seq(func(v int, err error) bool {
 			count++
 			return false
 		})
 		assert.Equal(t, 1, count)
 	})
 
-	t.Run("ReplaceFuncSeq", func(t *testing.T) {
+	t.Run("GroupSeqCapped", func(t *testing.T) {
 		data := []int{1, 2, 3}
-		seq := slice_utils.ReplaceFuncSeq(slices.Values(data), func(v int) int { return v })
+		seq := slice_utils.GroupSeqCapped(slices.Values(data), func(v int) int { return v }, 1)
+		count := 0
+		seq(func(k int, g []int) bool {
+			count++
+			return false
+		})
+		assert.Equal(t, 1, count)
+	})
+
+	t.Run("JoinSeq2", func(t *testing.T) {
+		data := []int{1, 2, 3}
+		seq := slice_utils.JoinSeq2(slices.All(data), slices.All(data))
+		count := 0
+		seq(func(k int, p slice_utils.JoinPair[int, int]) bool {
+			count++
+			return false
+		})
+		assert.Equal(t, 1, count)
+	})
+
+	t.Run("ConvertWhileSeq", func(t *testing.T) {
+		data := []int{1, 2, 3}
+		seq := slice_utils.ConvertWhileSeq(slices.Values(data), func(v int) (int, bool) { return v, true })
 		count := 0
 		seq(func(v int) bool {
 			count++
@@ -315,9 +2040,20 @@ func TestEarlyTermination(t *testing.T) {
 		assert.Equal(t, 1, count)
 	})
 
-	t.Run("ReplaceSeq", func(t *testing.T) {
+	t.Run("MarkDuplicatesSeq", func(t *testing.T) {
 		data := []int{1, 2, 3}
-		seq := slice_utils.ReplaceSeq(slices.Values(data), map[int]int{})
+		seq := slice_utils.MarkDuplicatesSeq(slices.Values(data))
+		count := 0
+		seq(func(v int, dup bool) bool {
+			count++
+			return false
+		})
+		assert.Equal(t, 1, count)
+	})
+
+	t.Run("ReplaceWhereSeq", func(t *testing.T) {
+		data := []int{1, 2, 3}
+		seq := slice_utils.ReplaceWhereSeq(slices.Values(data), func(v int) bool { return true }, 0)
 		count := 0
 		seq(func(v int) bool {
 			count++
@@ -326,9 +2062,20 @@ func TestEarlyTermination(t *testing.T) {
 		assert.Equal(t, 1, count)
 	})
 
-	t.Run("ReplaceSeq_Match", func(t *testing.T) {
+	t.Run("BatchTimeSeq", func(t *testing.T) {
 		data := []int{1, 2, 3}
-		seq := slice_utils.ReplaceSeq(slices.Values(data), map[int]int{1: 10})
+		seq := slice_utils.BatchTimeSeq(slices.Values(data), 1, time.Hour)
+		count := 0
+		seq(func(v []int) bool {
+			count++
+			return false
+		})
+		assert.Equal(t, 1, count)
+	})
+
+	t.Run("DropLastSeq", func(t *testing.T) {
+		data := []int{1, 2, 3}
+		seq := slice_utils.DropLastSeq(slices.Values(data), 1)
 		count := 0
 		seq(func(v int) bool {
 			count++
@@ -337,9 +2084,9 @@ func TestEarlyTermination(t *testing.T) {
 		assert.Equal(t, 1, count)
 	})
 
-	t.Run("ConvertSeq", func(t *testing.T) {
+	t.Run("ChunkMapSeq", func(t *testing.T) {
 		data := []int{1, 2, 3}
-		seq := slice_utils.ConvertSeq(slices.Values(data), func(v int) int { return v })
+		seq := slice_utils.ChunkMapSeq(slices.Values(data), 1, func(chunk []int) int { return len(chunk) })
 		count := 0
 		seq(func(v int) bool {
 			count++
@@ -348,14 +2095,49 @@ func TestEarlyTermination(t *testing.T) {
 		assert.Equal(t, 1, count)
 	})
 
-	t.Run("AnySeq", func(t *testing.T) {
+	t.Run("SlidingChunkSeq", func(t *testing.T) {
 		data := []int{1, 2, 3}
-		seq := slice_utils.AnySeq(slices.Values(data))
+		seq := slice_utils.SlidingChunkSeq(slices.Values(data), 1, 1)
 		count := 0
-		seq(func(v any) bool {
+		seq(func(v []int) bool {
 			count++
 			return false
 		})
 		assert.Equal(t, 1, count)
 	})
+
+	t.Run("PrependSeq", func(t *testing.T) {
+		for _, stopAfter := range []int{1, 2} {
+			pulled := 0
+			src := slice_utils.TapSeq(slices.Values([]int{3, 4}), func(int) { pulled++ })
+			seq := slice_utils.PrependSeq(src, 1, 2)
+			var got []int
+			seq(func(v int) bool {
+				got = append(got, v)
+				return len(got) < stopAfter
+			})
+			assert.Equal(t, []int{1, 2}[:stopAfter], got)
+			assert.Equal(t, 0, pulled)
+		}
+	})
+
+	t.Run("AppendSeq", func(t *testing.T) {
+		pulled := 0
+		src := slice_utils.TapSeq(slices.Values([]int{1}), func(int) { pulled++ })
+		seq := slice_utils.AppendSeq(src, 2, 3, 4)
+		var got []int
+		seq(func(v int) bool {
+			got = append(got, v)
+			return len(got) < 3
+		})
+		assert.Equal(t, []int{1, 2, 3}, got)
+		assert.Equal(t, 1, pulled)
+
+		got = nil
+		seq(func(v int) bool {
+			got = append(got, v)
+			return false
+		})
+		assert.Equal(t, []int{1}, got)
+	})
 }
@@ -6,10 +6,20 @@
 package slice_utils_test
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"math"
 	"regexp"
+	"slices"
 	"sort"
+	"strconv"
+	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/zauberhaus/slice_utils"
@@ -48,20 +58,20 @@ func TestSelect(t *testing.T) {
 			name:  "select no numbers",
 			input: []int{1, 2, 3},
 			f:     func(val int) bool { return false },
-			want:  []int{},
+			want:  nil,
 		},
 		{
 			name:  "empty slice",
 			input: []int{},
 			f:     func(val int) bool { return true },
-			want:  []int{},
+			want:  nil,
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			got := slice_utils.Select(tt.input, tt.f)
-			assert.ElementsMatch(t, tt.want, got, "Select() should return matching elements") // Use ElementsMatch for slice comparison where order might not matter
+			assert.Equal(t, tt.want, got, "Select() should return matching elements")
 		})
 	}
 }
@@ -582,6 +592,14 @@ func TestDuplicates(t *testing.T) {
 		got := slice_utils.Duplicates(input)
 		assert.ElementsMatch(t, want, got, "Duplicates() should return all unique duplicate string elements")
 	})
+
+	t.Run("same order as DuplicateSeq", func(t *testing.T) {
+		input := []int{5, 1, 2, 1, 5, 3, 2, 1}
+		want := slices.Collect(slice_utils.DuplicateSeq(slices.Values(input)))
+		got := slice_utils.Duplicates(input)
+		assert.Equal(t, []int{1, 5, 2}, got)
+		assert.Equal(t, want, got)
+	})
 }
 
 func TestDeduplicate(t *testing.T) {
@@ -999,3 +1017,1993 @@ func TestTo(t *testing.T) {
 		assert.Equal(t, want, got)
 	})
 }
+
+func TestFoldRight(t *testing.T) {
+	t.Run("right to left string assembly", func(t *testing.T) {
+		input := []string{"a", "b", "c"}
+		got := slice_utils.FoldRight(input, "", func(val string, acc string) string {
+			return acc + val
+		})
+		assert.Equal(t, "cba", got)
+	})
+
+	t.Run("right nested structure", func(t *testing.T) {
+		input := []int{1, 2, 3}
+		got := slice_utils.FoldRight(input, "nil", func(val int, acc string) string {
+			return fmt.Sprintf("(%d %s)", val, acc)
+		})
+		assert.Equal(t, "(1 (2 (3 nil)))", got)
+	})
+
+	t.Run("empty slice", func(t *testing.T) {
+		got := slice_utils.FoldRight([]int{}, 42, func(val int, acc int) int { return acc + val })
+		assert.Equal(t, 42, got)
+	})
+}
+
+func TestFilterIndex(t *testing.T) {
+	tests := []struct {
+		name  string
+		input []string
+		f     func(i int, val string) bool
+		want  []string
+	}{
+		{
+			name:  "every other element",
+			input: []string{"a", "b", "c", "d", "e"},
+			f:     func(i int, val string) bool { return i%2 == 0 },
+			want:  []string{"a", "c", "e"},
+		},
+		{
+			name:  "index and value",
+			input: []string{"a", "b", "c", "d"},
+			f:     func(i int, val string) bool { return i > 0 && val != "c" },
+			want:  []string{"b", "d"},
+		},
+		{
+			name:  "no match",
+			input: []string{"a", "b"},
+			f:     func(i int, val string) bool { return false },
+			want:  []string{},
+		},
+		{
+			name:  "empty slice",
+			input: []string{},
+			f:     func(i int, val string) bool { return true },
+			want:  []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := slice_utils.FilterIndex(tt.input, tt.f)
+			assert.NotNil(t, got)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestReject(t *testing.T) {
+	tests := []struct {
+		name  string
+		input []int
+		f     func(val int) bool
+		want  []int
+	}{
+		{
+			name:  "reject even numbers",
+			input: []int{1, 2, 3, 4, 5},
+			f:     func(val int) bool { return val%2 == 0 },
+			want:  []int{1, 3, 5},
+		},
+		{
+			name:  "reject all numbers",
+			input: []int{1, 2, 3},
+			f:     func(val int) bool { return true },
+			want:  nil,
+		},
+		{
+			name:  "reject no numbers",
+			input: []int{1, 2, 3},
+			f:     func(val int) bool { return false },
+			want:  []int{1, 2, 3},
+		},
+		{
+			name:  "empty slice",
+			input: []int{},
+			f:     func(val int) bool { return false },
+			want:  nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := slice_utils.Reject(tt.input, tt.f)
+			assert.Equal(t, tt.want, got, "Reject() should return non-matching elements")
+		})
+	}
+}
+
+func TestCountValue(t *testing.T) {
+	input := []int{1, 2, 3, 2, 2, 4}
+	assert.Equal(t, 3, slice_utils.CountValue(input, 2))
+	assert.Equal(t, 1, slice_utils.CountValue(input, 4))
+	assert.Equal(t, 0, slice_utils.CountValue(input, 5))
+	assert.Equal(t, 0, slice_utils.CountValue([]int{}, 1))
+}
+
+func TestCountValues(t *testing.T) {
+	tests := []struct {
+		name  string
+		input []string
+		vals  []string
+		want  int
+	}{
+		{
+			name:  "single value",
+			input: []string{"a", "b", "a", "c"},
+			vals:  []string{"a"},
+			want:  2,
+		},
+		{
+			name:  "multiple values",
+			input: []string{"a", "b", "a", "c"},
+			vals:  []string{"a", "c"},
+			want:  3,
+		},
+		{
+			name:  "duplicate search values",
+			input: []string{"a", "b", "a", "c"},
+			vals:  []string{"b", "b"},
+			want:  1,
+		},
+		{
+			name:  "no values",
+			input: []string{"a", "b"},
+			vals:  []string{},
+			want:  0,
+		},
+		{
+			name:  "empty slice",
+			input: []string{},
+			vals:  []string{"a"},
+			want:  0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := slice_utils.CountValues(tt.input, tt.vals...)
+			assert.Equal(t, tt.want, got, "CountValues() should return the total occurrences")
+		})
+	}
+}
+
+func TestAggregateFunc(t *testing.T) {
+	t.Run("max", func(t *testing.T) {
+		input := []int{3, 7, 2, 5}
+		got, err := slice_utils.AggregateFunc(input, 0, func(acc int, val int) (int, error) {
+			return max(acc, val), nil
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, 7, got)
+	})
+
+	t.Run("concatenate", func(t *testing.T) {
+		input := []int{1, 2, 3}
+		got, err := slice_utils.AggregateFunc(input, []string{}, func(acc []string, val int) ([]string, error) {
+			return append(acc, fmt.Sprint(val)), nil
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"1", "2", "3"}, got)
+	})
+
+	t.Run("empty slice", func(t *testing.T) {
+		got, err := slice_utils.AggregateFunc([]int{}, 10, func(acc int, val int) (int, error) {
+			return acc + val, nil
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, 10, got)
+	})
+
+	t.Run("function returns error", func(t *testing.T) {
+		calls := 0
+		got, err := slice_utils.AggregateFunc([]int{1, -2, 3}, 0, func(acc int, val int) (int, error) {
+			calls++
+			if val < 0 {
+				return 0, errors.New("negative value")
+			}
+			return acc + val, nil
+		})
+		assert.Error(t, err)
+		assert.Equal(t, 0, got)
+		assert.Equal(t, 2, calls)
+	})
+}
+
+func TestWriteCSV(t *testing.T) {
+	type record struct {
+		ID   int
+		Name string
+	}
+
+	row := func(r record) []string {
+		return []string{strconv.Itoa(r.ID), r.Name}
+	}
+
+	tests := []struct {
+		name   string
+		input  []record
+		header []string
+		want   string
+	}{
+		{
+			name:   "with header",
+			input:  []record{{1, "a"}, {2, "b,c"}},
+			header: []string{"id", "name"},
+			want:   "id,name\n1,a\n2,\"b,c\"\n",
+		},
+		{
+			name:  "without header",
+			input: []record{{1, "a"}},
+			want:  "1,a\n",
+		},
+		{
+			name:   "empty slice",
+			input:  []record{},
+			header: []string{"id", "name"},
+			want:   "id,name\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			err := slice_utils.WriteCSV(&buf, tt.input, tt.header, row)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, buf.String())
+		})
+	}
+}
+
+func TestToJSON(t *testing.T) {
+	type dto struct {
+		ID string `json:"id"`
+	}
+
+	t.Run("project and marshal", func(t *testing.T) {
+		got, err := slice_utils.ToJSON([]int{1, 2}, func(v int) dto {
+			return dto{ID: strconv.Itoa(v)}
+		})
+		assert.NoError(t, err)
+		assert.JSONEq(t, `[{"id":"1"},{"id":"2"}]`, string(got))
+	})
+
+	t.Run("empty slice", func(t *testing.T) {
+		got, err := slice_utils.ToJSON([]int{}, func(v int) int { return v })
+		assert.NoError(t, err)
+		assert.Equal(t, `[]`, string(got))
+	})
+
+	t.Run("marshal error", func(t *testing.T) {
+		_, err := slice_utils.ToJSON([]int{1}, func(v int) chan int { return nil })
+		var typeErr *json.UnsupportedTypeError
+		assert.ErrorAs(t, err, &typeErr)
+	})
+}
+
+func TestPercentile(t *testing.T) {
+	tests := []struct {
+		name   string
+		input  []int
+		p      float64
+		want   float64
+		wantOk bool
+	}{
+		{
+			name:   "minimum",
+			input:  []int{5, 1, 4, 2, 3},
+			p:      0,
+			want:   1,
+			wantOk: true,
+		},
+		{
+			name:   "maximum",
+			input:  []int{5, 1, 4, 2, 3},
+			p:      100,
+			want:   5,
+			wantOk: true,
+		},
+		{
+			name:   "exact rank",
+			input:  []int{5, 1, 4, 2, 3},
+			p:      75,
+			want:   4,
+			wantOk: true,
+		},
+		{
+			name:   "interpolated",
+			input:  []int{10, 20, 30, 40},
+			p:      50,
+			want:   25,
+			wantOk: true,
+		},
+		{
+			name:   "p95",
+			input:  []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11},
+			p:      95,
+			want:   10.5,
+			wantOk: true,
+		},
+		{
+			name:   "clamped",
+			input:  []int{1, 2, 3},
+			p:      150,
+			want:   3,
+			wantOk: true,
+		},
+		{
+			name:   "NaN",
+			input:  []int{1, 2, 3},
+			p:      math.NaN(),
+			want:   0,
+			wantOk: false,
+		},
+		{
+			name:   "single element",
+			input:  []int{7},
+			p:      99,
+			want:   7,
+			wantOk: true,
+		},
+		{
+			name:   "empty slice",
+			input:  []int{},
+			p:      50,
+			want:   0,
+			wantOk: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			input := slices.Clone(tt.input)
+			got, ok := slice_utils.Percentile(input, tt.p)
+			assert.Equal(t, tt.wantOk, ok)
+			assert.InDelta(t, tt.want, got, 1e-9)
+			assert.Equal(t, tt.input, input, "Percentile() should not modify the input")
+		})
+	}
+}
+
+func TestMedian(t *testing.T) {
+	got, ok := slice_utils.Median([]float64{3, 1, 2})
+	assert.True(t, ok)
+	assert.Equal(t, 2.0, got)
+
+	got, ok = slice_utils.Median([]float64{4, 1, 2, 3})
+	assert.True(t, ok)
+	assert.Equal(t, 2.5, got)
+
+	_, ok = slice_utils.Median([]int{})
+	assert.False(t, ok)
+}
+
+func TestVariance(t *testing.T) {
+	tests := []struct {
+		name   string
+		input  []float64
+		want   float64
+		wantOk bool
+	}{
+		{
+			name:   "population variance",
+			input:  []float64{2, 4, 4, 4, 5, 5, 7, 9},
+			want:   4,
+			wantOk: true,
+		},
+		{
+			name:   "constant values",
+			input:  []float64{3, 3, 3},
+			want:   0,
+			wantOk: true,
+		},
+		{
+			name:   "large offset",
+			input:  []float64{1e9 + 4, 1e9 + 7, 1e9 + 13, 1e9 + 16},
+			want:   22.5,
+			wantOk: true,
+		},
+		{
+			name:   "empty slice",
+			input:  []float64{},
+			want:   0,
+			wantOk: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := slice_utils.Variance(tt.input)
+			assert.Equal(t, tt.wantOk, ok)
+			assert.InDelta(t, tt.want, got, 1e-9)
+		})
+	}
+}
+
+func TestStdDev(t *testing.T) {
+	got, ok := slice_utils.StdDev([]int{2, 4, 4, 4, 5, 5, 7, 9})
+	assert.True(t, ok)
+	assert.InDelta(t, 2.0, got, 1e-9)
+
+	_, ok = slice_utils.StdDev([]int{})
+	assert.False(t, ok)
+}
+
+func TestClamp(t *testing.T) {
+	tests := []struct {
+		name  string
+		input []int
+		lo    int
+		hi    int
+		want  []int
+	}{
+		{
+			name:  "clamp values",
+			input: []int{-5, 0, 5, 10, 15},
+			lo:    0,
+			hi:    10,
+			want:  []int{0, 0, 5, 10, 10},
+		},
+		{
+			name:  "all in range",
+			input: []int{1, 2, 3},
+			lo:    0,
+			hi:    10,
+			want:  []int{1, 2, 3},
+		},
+		{
+			name:  "swapped bounds",
+			input: []int{-5, 5, 15},
+			lo:    10,
+			hi:    0,
+			want:  []int{0, 5, 10},
+		},
+		{
+			name:  "empty slice",
+			input: []int{},
+			lo:    0,
+			hi:    10,
+			want:  []int{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			input := slices.Clone(tt.input)
+			got := slice_utils.Clamp(input, tt.lo, tt.hi)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.input, input, "Clamp() should not modify the input")
+		})
+	}
+}
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		name  string
+		input []int
+		want  []float64
+	}{
+		{
+			name:  "scale to unit range",
+			input: []int{10, 20, 15, 30},
+			want:  []float64{0, 0.5, 0.25, 1},
+		},
+		{
+			name:  "negative values",
+			input: []int{-2, 0, 2},
+			want:  []float64{0, 0.5, 1},
+		},
+		{
+			name:  "all equal",
+			input: []int{4, 4, 4},
+			want:  []float64{0, 0, 0},
+		},
+		{
+			name:  "empty slice",
+			input: []int{},
+			want:  []float64{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := slice_utils.Normalize(tt.input)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestRunningMax(t *testing.T) {
+	assert.Equal(t, []int{3, 3, 5, 5, 7}, slice_utils.RunningMax([]int{3, 1, 5, 2, 7}))
+	assert.Equal(t, []int{-3, -1}, slice_utils.RunningMax([]int{-3, -1}))
+	assert.Equal(t, []int{}, slice_utils.RunningMax([]int{}))
+}
+
+func TestRunningMin(t *testing.T) {
+	assert.Equal(t, []int{3, 1, 1, 1, 1}, slice_utils.RunningMin([]int{3, 1, 5, 2, 7}))
+	assert.Equal(t, []string{"b", "a"}, slice_utils.RunningMin([]string{"b", "a"}))
+	assert.Equal(t, []int{}, slice_utils.RunningMin([]int{}))
+}
+
+func TestDotProduct(t *testing.T) {
+	got, err := slice_utils.DotProduct([]int{1, 2, 3}, []int{4, 5, 6})
+	assert.NoError(t, err)
+	assert.Equal(t, 32, got)
+
+	got, err = slice_utils.DotProduct([]int{}, []int{})
+	assert.NoError(t, err)
+	assert.Equal(t, 0, got)
+
+	_, err = slice_utils.DotProduct([]int{1, 2}, []int{1})
+	assert.ErrorIs(t, err, slice_utils.ErrLengthMismatch)
+}
+
+func TestVectorOperations(t *testing.T) {
+	tests := []struct {
+		name    string
+		f       func(a, b []float64) ([]float64, error)
+		a       []float64
+		b       []float64
+		want    []float64
+		wantErr bool
+	}{
+		{
+			name: "add",
+			f:    slice_utils.AddVec[float64],
+			a:    []float64{1, 2, 3},
+			b:    []float64{0.5, 0.5, 1},
+			want: []float64{1.5, 2.5, 4},
+		},
+		{
+			name: "sub",
+			f:    slice_utils.SubVec[float64],
+			a:    []float64{1, 2, 3},
+			b:    []float64{0.5, 0.5, 1},
+			want: []float64{0.5, 1.5, 2},
+		},
+		{
+			name: "mul",
+			f:    slice_utils.MulVec[float64],
+			a:    []float64{1, 2, 3},
+			b:    []float64{0.5, 0.5, 1},
+			want: []float64{0.5, 1, 3},
+		},
+		{
+			name: "empty",
+			f:    slice_utils.AddVec[float64],
+			a:    []float64{},
+			b:    []float64{},
+			want: []float64{},
+		},
+		{
+			name:    "length mismatch",
+			f:       slice_utils.MulVec[float64],
+			a:       []float64{1, 2},
+			b:       []float64{1},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.f(tt.a, tt.b)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, slice_utils.ErrLengthMismatch)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func TestBinarySearch(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     []int
+		target    int
+		wantIndex int
+		wantFound bool
+	}{
+		{
+			name:      "found",
+			input:     []int{1, 3, 5, 7},
+			target:    5,
+			wantIndex: 2,
+			wantFound: true,
+		},
+		{
+			name:      "insertion point",
+			input:     []int{1, 3, 5, 7},
+			target:    4,
+			wantIndex: 2,
+			wantFound: false,
+		},
+		{
+			name:      "after last",
+			input:     []int{1, 3, 5, 7},
+			target:    9,
+			wantIndex: 4,
+			wantFound: false,
+		},
+		{
+			name:      "empty slice",
+			input:     []int{},
+			target:    1,
+			wantIndex: 0,
+			wantFound: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			i, found := slice_utils.BinarySearch(tt.input, tt.target)
+			assert.Equal(t, tt.wantIndex, i)
+			assert.Equal(t, tt.wantFound, found)
+		})
+	}
+}
+
+func TestBinarySearchFunc(t *testing.T) {
+	type entry struct {
+		Key   string
+		Value int
+	}
+
+	input := []entry{{"a", 1}, {"c", 3}, {"e", 5}}
+	search := func(key string) func(e entry) int {
+		return func(e entry) int {
+			return strings.Compare(e.Key, key)
+		}
+	}
+
+	i, found := slice_utils.BinarySearchFunc(input, search("c"))
+	assert.Equal(t, 1, i)
+	assert.True(t, found)
+
+	i, found = slice_utils.BinarySearchFunc(input, search("d"))
+	assert.Equal(t, 2, i)
+	assert.False(t, found)
+}
+
+func TestInsertSorted(t *testing.T) {
+	tests := []struct {
+		name  string
+		input []int
+		val   int
+		want  []int
+	}{
+		{
+			name:  "middle",
+			input: []int{1, 3, 5},
+			val:   4,
+			want:  []int{1, 3, 4, 5},
+		},
+		{
+			name:  "front",
+			input: []int{1, 3, 5},
+			val:   0,
+			want:  []int{0, 1, 3, 5},
+		},
+		{
+			name:  "end",
+			input: []int{1, 3, 5},
+			val:   6,
+			want:  []int{1, 3, 5, 6},
+		},
+		{
+			name:  "duplicate",
+			input: []int{1, 3, 5},
+			val:   3,
+			want:  []int{1, 3, 3, 5},
+		},
+		{
+			name:  "empty slice",
+			input: []int{},
+			val:   1,
+			want:  []int{1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			input := slices.Clone(tt.input)
+			got := slice_utils.InsertSorted(input, tt.val)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.input, input, "InsertSorted() should not modify the input")
+		})
+	}
+}
+
+func TestInsertSortedFunc(t *testing.T) {
+	type score struct {
+		Name   string
+		Points int
+	}
+
+	desc := func(a, b score) int { return b.Points - a.Points }
+	input := []score{{"a", 30}, {"b", 20}, {"c", 10}}
+
+	got := slice_utils.InsertSortedFunc(input, score{"d", 20}, desc)
+	assert.Equal(t, []score{{"a", 30}, {"b", 20}, {"d", 20}, {"c", 10}}, got)
+	assert.Len(t, input, 3)
+}
+
+func TestSelectConvertAllocations(t *testing.T) {
+	input := make([]int, 1000)
+	for i := range input {
+		input[i] = i
+	}
+
+	convert := testing.AllocsPerRun(10, func() {
+		slice_utils.Convert(input, func(v int) int { return v * 2 })
+	})
+	assert.LessOrEqual(t, convert, 3.0)
+
+	sel := testing.AllocsPerRun(10, func() {
+		slice_utils.Select(input, func(v int) bool { return v%2 == 0 })
+	})
+	assert.LessOrEqual(t, sel, 3.0)
+}
+
+func TestSelectCapacity(t *testing.T) {
+	input := make([]int, 1<<20)
+	input[42] = 1
+
+	got := slice_utils.Select(input, func(v int) bool { return v != 0 })
+	assert.Equal(t, []int{1}, got)
+	assert.LessOrEqual(t, cap(got), 1024)
+}
+
+func TestForEachParallel(t *testing.T) {
+	t.Run("all elements", func(t *testing.T) {
+		var sum atomic.Int64
+		err := slice_utils.ForEachParallel(context.Background(), []int{1, 2, 3, 4, 5}, 2, func(ctx context.Context, v int) error {
+			sum.Add(int64(v))
+			return nil
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, int64(15), sum.Load())
+	})
+
+	t.Run("worker limit", func(t *testing.T) {
+		var running, peak atomic.Int32
+		err := slice_utils.ForEachParallel(context.Background(), make([]int, 20), 3, func(ctx context.Context, v int) error {
+			n := running.Add(1)
+			for {
+				p := peak.Load()
+				if n <= p || peak.CompareAndSwap(p, n) {
+					break
+				}
+			}
+			time.Sleep(time.Millisecond)
+			running.Add(-1)
+			return nil
+		})
+		assert.NoError(t, err)
+		assert.LessOrEqual(t, peak.Load(), int32(3))
+	})
+
+	t.Run("first error cancels", func(t *testing.T) {
+		var calls atomic.Int32
+		err := slice_utils.ForEachParallel(context.Background(), make([]int, 100), 1, func(ctx context.Context, v int) error {
+			if calls.Add(1) == 2 {
+				return errors.New("failed")
+			}
+			return ctx.Err()
+		})
+		assert.EqualError(t, err, "failed")
+		assert.Less(t, calls.Load(), int32(100))
+	})
+
+	t.Run("empty slice", func(t *testing.T) {
+		err := slice_utils.ForEachParallel(context.Background(), []int{}, 2, func(ctx context.Context, v int) error {
+			return errors.New("unexpected")
+		})
+		assert.NoError(t, err)
+	})
+
+	t.Run("parent context cancelled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		var calls atomic.Int32
+		err := slice_utils.ForEachParallel(ctx, []int{1, 2, 3}, 2, func(ctx context.Context, v int) error {
+			calls.Add(1)
+			return nil
+		})
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.Equal(t, int32(0), calls.Load())
+	})
+
+	t.Run("parent context cancelled mid-loop", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		var calls atomic.Int32
+		err := slice_utils.ForEachParallel(ctx, make([]int, 100), 1, func(ctx context.Context, v int) error {
+			if calls.Add(1) == 2 {
+				cancel()
+			}
+			return nil
+		})
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.Less(t, calls.Load(), int32(100))
+	})
+}
+
+func TestContainsValue(t *testing.T) {
+	input := []int{1, 2, 42}
+	assert.True(t, slice_utils.ContainsValue(input, 42))
+	assert.False(t, slice_utils.ContainsValue(input, 4))
+	assert.False(t, slice_utils.ContainsValue([]int{}, 1))
+}
+
+func TestContainsAll(t *testing.T) {
+	input := []string{"a", "b", "c"}
+	assert.True(t, slice_utils.ContainsAll(input, "a", "c"))
+	assert.True(t, slice_utils.ContainsAll(input))
+	assert.False(t, slice_utils.ContainsAll(input, "a", "d"))
+	assert.False(t, slice_utils.ContainsAll([]string{}, "a"))
+	assert.True(t, slice_utils.ContainsAll(input, "b", "b"))
+	assert.True(t, slice_utils.ContainsAll([]string{"a"}, "a", "a"))
+	assert.False(t, slice_utils.ContainsAll([]string{"a", "b"}, "a", "b", "c"))
+}
+
+func TestContainsAny(t *testing.T) {
+	input := []string{"a", "b", "c"}
+	assert.True(t, slice_utils.ContainsAny(input, "d", "c"))
+	assert.False(t, slice_utils.ContainsAny(input, "d", "e"))
+	assert.False(t, slice_utils.ContainsAny(input))
+	assert.False(t, slice_utils.ContainsAny([]string{}, "a"))
+}
+
+func TestFilterMap(t *testing.T) {
+	parse := func(val string) (int, bool) {
+		i, err := strconv.Atoi(val)
+		return i, err == nil
+	}
+
+	tests := []struct {
+		name  string
+		input []string
+		want  []int
+	}{
+		{
+			name:  "keep parsed values",
+			input: []string{"1", "x", "3", "", "5"},
+			want:  []int{1, 3, 5},
+		},
+		{
+			name:  "nothing parsed",
+			input: []string{"a", "b"},
+			want:  []int{},
+		},
+		{
+			name:  "empty slice",
+			input: []string{},
+			want:  []int{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := slice_utils.FilterMap(tt.input, parse)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestMap(t *testing.T) {
+	got := slice_utils.Map([]int{1, 2, 3}, func(v int) string { return strconv.Itoa(v * 2) })
+	assert.Equal(t, []string{"2", "4", "6"}, got)
+
+	assert.Equal(t, []string{}, slice_utils.Map([]int{}, strconv.Itoa))
+}
+
+func TestFilter(t *testing.T) {
+	got := slice_utils.Filter([]int{1, 2, 3, 4}, func(v int) bool { return v%2 == 0 })
+	assert.Equal(t, []int{2, 4}, got)
+
+	assert.Empty(t, slice_utils.Filter([]int{1, 3}, func(v int) bool { return v%2 == 0 }))
+}
+
+func TestForEachWhile(t *testing.T) {
+	var seen []int
+	slice_utils.ForEachWhile([]int{1, 2, -1, 3}, func(v int) bool {
+		if v < 0 {
+			return false
+		}
+		seen = append(seen, v)
+		return true
+	})
+	assert.Equal(t, []int{1, 2}, seen)
+
+	calls := 0
+	slice_utils.ForEachWhile([]int{1, 2, 3}, func(v int) bool {
+		calls++
+		return true
+	})
+	assert.Equal(t, 3, calls)
+}
+
+func TestPartitionN(t *testing.T) {
+	tests := []struct {
+		name  string
+		input []int
+		n     int
+		want  [][]int
+	}{
+		{
+			name:  "divisible",
+			input: []int{1, 2, 3, 4, 5, 6},
+			n:     3,
+			want:  [][]int{{1, 2}, {3, 4}, {5, 6}},
+		},
+		{
+			name:  "earlier parts get the rest",
+			input: []int{1, 2, 3, 4, 5, 6, 7},
+			n:     3,
+			want:  [][]int{{1, 2, 3}, {4, 5}, {6, 7}},
+		},
+		{
+			name:  "more parts than elements",
+			input: []int{1, 2},
+			n:     4,
+			want:  [][]int{{1}, {2}, {}, {}},
+		},
+		{
+			name:  "zero parts",
+			input: []int{1, 2, 3},
+			n:     0,
+			want:  [][]int{{1, 2, 3}},
+		},
+		{
+			name:  "empty slice",
+			input: []int{},
+			n:     2,
+			want:  [][]int{{}, {}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := slice_utils.PartitionN(tt.input, tt.n)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestFlattenMap(t *testing.T) {
+	input := map[string][]int{"odd": {1, 3}, "even": {2, 4}, "none": nil}
+	got := slice_utils.FlattenMap(input)
+	assert.ElementsMatch(t, []int{1, 2, 3, 4}, got)
+
+	assert.Equal(t, []int{}, slice_utils.FlattenMap(map[string][]int{}))
+}
+
+func TestFlattenMapSorted(t *testing.T) {
+	input := slice_utils.Group([]int{1, 2, 3, 4, 5}, func(v int) string {
+		if v%2 == 0 {
+			return "even"
+		}
+		return "odd"
+	})
+	input["empty"] = nil
+
+	got := slice_utils.FlattenMapSorted(input)
+	assert.Equal(t, []int{2, 4, 1, 3, 5}, got)
+
+	assert.Equal(t, []int{}, slice_utils.FlattenMapSorted(map[int][]int{}))
+}
+
+func TestEntries(t *testing.T) {
+	got := slice_utils.Entries(map[string]int{"a": 1, "b": 2})
+	assert.ElementsMatch(t, []slice_utils.Entry[string, int]{{"a", 1}, {"b", 2}}, got)
+
+	empty := slice_utils.Entries(map[string]int{})
+	assert.NotNil(t, empty)
+	assert.Empty(t, empty)
+}
+
+func TestEntriesSorted(t *testing.T) {
+	got := slice_utils.EntriesSorted(map[int]string{3: "c", 1: "a", 2: "b"})
+	assert.Equal(t, []slice_utils.Entry[int, string]{{1, "a"}, {2, "b"}, {3, "c"}}, got)
+
+	empty := slice_utils.EntriesSorted(map[int]string{})
+	assert.NotNil(t, empty)
+	assert.Empty(t, empty)
+}
+
+func TestFromPairs(t *testing.T) {
+	tests := []struct {
+		name  string
+		input [][2]string
+		want  map[string]string
+	}{
+		{
+			name:  "pairs",
+			input: slice_utils.Pairs("k1", "v1", "k2", "v2"),
+			want:  map[string]string{"k1": "v1", "k2": "v2"},
+		},
+		{
+			name:  "last wins",
+			input: [][2]string{{"k", "a"}, {"k", "b"}},
+			want:  map[string]string{"k": "b"},
+		},
+		{
+			name:  "empty",
+			input: [][2]string{},
+			want:  map[string]string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := slice_utils.FromPairs(tt.input)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestPairsPadded(t *testing.T) {
+	got := slice_utils.PairsPadded("<missing>", "a", "b", "c")
+	assert.Equal(t, [][2]string{{"a", "b"}, {"c", "<missing>"}}, got)
+
+	got = slice_utils.PairsPadded("<missing>", "a", "b")
+	assert.Equal(t, [][2]string{{"a", "b"}}, got)
+
+	assert.Empty(t, slice_utils.PairsPadded(-1))
+}
+
+func TestPairsStrict(t *testing.T) {
+	got, err := slice_utils.PairsStrict("a", "b", "c", "d")
+	assert.NoError(t, err)
+	assert.Equal(t, [][2]string{{"a", "b"}, {"c", "d"}}, got)
+
+	_, err = slice_utils.PairsStrict("a", "b", "c")
+	assert.ErrorIs(t, err, slice_utils.ErrOddCount)
+
+	empty, err := slice_utils.PairsStrict[int]()
+	assert.NoError(t, err)
+	assert.Empty(t, empty)
+}
+
+func TestZipWith(t *testing.T) {
+	format := func(name string, score int) string {
+		return fmt.Sprintf("%s: %d", name, score)
+	}
+
+	tests := []struct {
+		name   string
+		names  []string
+		scores []int
+		want   []string
+	}{
+		{
+			name:   "equal length",
+			names:  []string{"a", "b"},
+			scores: []int{1, 2},
+			want:   []string{"a: 1", "b: 2"},
+		},
+		{
+			name:   "truncate to shorter",
+			names:  []string{"a", "b", "c"},
+			scores: []int{1},
+			want:   []string{"a: 1"},
+		},
+		{
+			name:   "empty",
+			names:  []string{},
+			scores: []int{1},
+			want:   []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := slice_utils.ZipWith(tt.names, tt.scores, format)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestFindLast(t *testing.T) {
+	type deploy struct {
+		ID      int
+		Success bool
+	}
+
+	input := []deploy{{1, true}, {2, false}, {3, true}, {4, false}}
+
+	got, ok := slice_utils.FindLast(input, func(d deploy) bool { return d.Success })
+	assert.True(t, ok)
+	assert.Equal(t, deploy{3, true}, got)
+
+	got, ok = slice_utils.FindLast(input, func(d deploy) bool { return d.ID > 10 })
+	assert.False(t, ok)
+	assert.Equal(t, deploy{}, got)
+
+	_, ok = slice_utils.FindLast([]deploy{}, func(d deploy) bool { return true })
+	assert.False(t, ok)
+}
+
+func TestSumBy(t *testing.T) {
+	type order struct {
+		ID     int
+		Amount float64
+	}
+
+	input := []order{{1, 10.5}, {2, 20}, {3, 0.25}}
+	assert.Equal(t, 30.75, slice_utils.SumBy(input, func(o order) float64 { return o.Amount }))
+	assert.Equal(t, 6, slice_utils.SumBy(input, func(o order) int { return o.ID }))
+	assert.Equal(t, 0, slice_utils.SumBy([]order{}, func(o order) int { return o.ID }))
+}
+
+func TestAverageBy(t *testing.T) {
+	type order struct {
+		Amount int8
+	}
+
+	input := []order{{100}, {100}, {101}}
+	got, ok := slice_utils.AverageBy(input, func(o order) int8 { return o.Amount })
+	assert.True(t, ok)
+	assert.InDelta(t, 100.333333, got, 1e-6)
+
+	_, ok = slice_utils.AverageBy([]order{}, func(o order) int8 { return o.Amount })
+	assert.False(t, ok)
+}
+
+func TestMinByMaxBy(t *testing.T) {
+	type player struct {
+		Name  string
+		Score int
+	}
+
+	input := []player{{"a", 5}, {"b", 9}, {"c", 1}, {"d", 9}, {"e", 1}}
+	score := func(p player) int { return p.Score }
+
+	got, ok := slice_utils.MinBy(input, score)
+	assert.True(t, ok)
+	assert.Equal(t, player{"c", 1}, got)
+
+	got, ok = slice_utils.MaxBy(input, score)
+	assert.True(t, ok)
+	assert.Equal(t, player{"b", 9}, got)
+
+	_, ok = slice_utils.MinBy([]player{}, score)
+	assert.False(t, ok)
+
+	_, ok = slice_utils.MaxBy([]player{}, score)
+	assert.False(t, ok)
+}
+
+func TestIntersperse(t *testing.T) {
+	tests := []struct {
+		name  string
+		input []int
+		want  []int
+	}{
+		{
+			name:  "multiple elements",
+			input: []int{1, 2, 3},
+			want:  []int{1, 0, 2, 0, 3},
+		},
+		{
+			name:  "single element",
+			input: []int{1},
+			want:  []int{1},
+		},
+		{
+			name:  "empty slice",
+			input: []int{},
+			want:  []int{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := slice_utils.Intersperse(tt.input, 0)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestChunkReduce(t *testing.T) {
+	sum := func(acc int, val int) int { return acc + val }
+
+	tests := []struct {
+		name  string
+		input []int
+		size  int
+		want  []int
+	}{
+		{
+			name:  "even chunks",
+			input: []int{1, 2, 3, 4, 5, 6},
+			size:  2,
+			want:  []int{3, 7, 11},
+		},
+		{
+			name:  "last chunk smaller",
+			input: []int{1, 2, 3, 4, 5},
+			size:  2,
+			want:  []int{3, 7, 5},
+		},
+		{
+			name:  "size zero",
+			input: []int{1, 2, 3},
+			size:  0,
+			want:  []int{6},
+		},
+		{
+			name:  "empty slice",
+			input: []int{},
+			size:  2,
+			want:  []int{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := slice_utils.ChunkReduce(tt.input, tt.size, 0, sum)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	t.Run("slice seed with spare capacity", func(t *testing.T) {
+		seed := make([]int, 0, 4)
+		collect := func(acc []int, val int) []int {
+			return append(slices.Clip(acc), val)
+		}
+
+		got := slice_utils.ChunkReduce([]int{1, 2, 3, 4}, 2, seed, collect)
+		assert.Equal(t, [][]int{{1, 2}, {3, 4}}, got)
+		assert.Equal(t, []int{0, 0, 0, 0}, seed[:4])
+	})
+}
+
+func TestTranspose(t *testing.T) {
+	tests := []struct {
+		name  string
+		input [][]int
+		want  [][]int
+	}{
+		{
+			name:  "rectangular",
+			input: [][]int{{1, 2, 3}, {4, 5, 6}},
+			want:  [][]int{{1, 4}, {2, 5}, {3, 6}},
+		},
+		{
+			name:  "ragged",
+			input: [][]int{{1, 2}, {3}, {4, 5, 6}},
+			want:  [][]int{{1, 3, 4}, {2, 0, 5}, {0, 0, 6}},
+		},
+		{
+			name:  "empty rows",
+			input: [][]int{{}, {}},
+			want:  [][]int{},
+		},
+		{
+			name:  "empty matrix",
+			input: [][]int{},
+			want:  [][]int{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := slice_utils.Transpose(tt.input)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestZipN(t *testing.T) {
+	tests := []struct {
+		name  string
+		input [][]string
+		want  [][]string
+	}{
+		{
+			name:  "three columns",
+			input: [][]string{{"1", "2"}, {"a", "b"}, {"x", "y"}},
+			want:  [][]string{{"1", "a", "x"}, {"2", "b", "y"}},
+		},
+		{
+			name:  "truncate to shortest",
+			input: [][]string{{"1", "2", "3"}, {"a"}},
+			want:  [][]string{{"1", "a"}},
+		},
+		{
+			name:  "single input",
+			input: [][]string{{"1", "2"}},
+			want:  [][]string{{"1"}, {"2"}},
+		},
+		{
+			name:  "no input",
+			input: [][]string{},
+			want:  [][]string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := slice_utils.ZipN(tt.input...)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestConvertIndex(t *testing.T) {
+	got := slice_utils.ConvertIndex([]string{"a", "b"}, func(i int, val string) string {
+		return fmt.Sprintf("%d:%s", i, val)
+	})
+	assert.Equal(t, []string{"0:a", "1:b"}, got)
+
+	assert.Equal(t, []int{}, slice_utils.ConvertIndex([]string{}, func(i int, val string) int { return i }))
+}
+
+func TestWeightedAverage(t *testing.T) {
+	tests := []struct {
+		name    string
+		values  []int
+		weights []float64
+		want    float64
+		wantErr error
+	}{
+		{
+			name:    "weighted",
+			values:  []int{1, 2, 3},
+			weights: []float64{3, 2, 1},
+			want:    10.0 / 6.0,
+		},
+		{
+			name:    "equal weights",
+			values:  []int{2, 4},
+			weights: []float64{0.5, 0.5},
+			want:    3,
+		},
+		{
+			name:    "length mismatch",
+			values:  []int{1, 2},
+			weights: []float64{1},
+			wantErr: slice_utils.ErrLengthMismatch,
+		},
+		{
+			name:    "zero weight",
+			values:  []int{1, 2},
+			weights: []float64{1, -1},
+			wantErr: slice_utils.ErrZeroWeight,
+		},
+		{
+			name:    "empty",
+			values:  []int{},
+			weights: []float64{},
+			wantErr: slice_utils.ErrZeroWeight,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := slice_utils.WeightedAverage(tt.values, tt.weights)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+				assert.InDelta(t, tt.want, got, 1e-9)
+			}
+		})
+	}
+}
+
+func TestHistogram(t *testing.T) {
+	tests := []struct {
+		name  string
+		input []float64
+		edges []float64
+		want  []int
+	}{
+		{
+			name:  "buckets",
+			input: []float64{5, 10, 15, 49.9, 50, 99, 100, 250},
+			edges: []float64{10, 50, 100},
+			want:  []int{1, 3, 2, 2},
+		},
+		{
+			name:  "NaN skipped",
+			input: []float64{-1, 0, 0.5, 1, 2, math.NaN()},
+			edges: []float64{0, 1},
+			want:  []int{1, 2, 2},
+		},
+		{
+			name:  "no edges",
+			input: []float64{1, 2},
+			edges: []float64{},
+			want:  []int{2},
+		},
+		{
+			name:  "empty slice",
+			input: []float64{},
+			edges: []float64{1, 2},
+			want:  []int{0, 0, 0},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := slice_utils.Histogram(tt.input, tt.edges)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestCumSum(t *testing.T) {
+	assert.Equal(t, []int{1, 3, 6}, slice_utils.CumSum([]int{1, 2, 3}))
+	assert.Equal(t, []float64{0.5, 0, 2}, slice_utils.CumSum([]float64{0.5, -0.5, 2}))
+	assert.Equal(t, []int{}, slice_utils.CumSum([]int{}))
+}
+
+func TestSelectN(t *testing.T) {
+	even := func(val int) bool { return val%2 == 0 }
+
+	tests := []struct {
+		name  string
+		input []int
+		n     int
+		want  []int
+	}{
+		{
+			name:  "first matches",
+			input: []int{1, 2, 3, 4, 5, 6, 8},
+			n:     2,
+			want:  []int{2, 4},
+		},
+		{
+			name:  "fewer matches than n",
+			input: []int{1, 2, 3},
+			n:     5,
+			want:  []int{2},
+		},
+		{
+			name:  "zero",
+			input: []int{2, 4},
+			n:     0,
+			want:  []int{},
+		},
+		{
+			name:  "negative",
+			input: []int{2, 4},
+			n:     -1,
+			want:  []int{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := slice_utils.SelectN(tt.input, tt.n, even)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	t.Run("stops after n matches", func(t *testing.T) {
+		calls := 0
+		slice_utils.SelectN([]int{2, 4, 6, 8}, 2, func(val int) bool {
+			calls++
+			return true
+		})
+		assert.Equal(t, 2, calls)
+	})
+}
+
+func TestToStrings(t *testing.T) {
+	t.Run("int", func(t *testing.T) {
+		assert.Equal(t, []string{"1", "2", "3"}, slice_utils.ToStrings([]int{1, 2, 3}))
+	})
+
+	t.Run("string", func(t *testing.T) {
+		assert.Equal(t, []string{"a", "b"}, slice_utils.ToStrings([]string{"a", "b"}))
+	})
+
+	t.Run("Stringer", func(t *testing.T) {
+		assert.Equal(t, []string{"val1", "val2"}, slice_utils.ToStrings([]MyStringer{1, 2}))
+	})
+
+	t.Run("mixed", func(t *testing.T) {
+		assert.Equal(t, []string{"1", "a", "1.5", "true"}, slice_utils.ToStrings([]any{1, "a", 1.5, true}))
+	})
+
+	t.Run("empty slice", func(t *testing.T) {
+		assert.Equal(t, []string{}, slice_utils.ToStrings([]int{}))
+	})
+}
+
+func TestToMapValues(t *testing.T) {
+	type record struct {
+		ID   int
+		Name string
+	}
+
+	input := []record{{1, "a"}, {2, "b"}, {1, "c"}}
+	got := slice_utils.ToMapValues(input, func(r record) int { return r.ID }, func(r record) string { return r.Name })
+	assert.Equal(t, map[int]string{1: "c", 2: "b"}, got)
+
+	empty := slice_utils.ToMapValues([]record{}, func(r record) int { return r.ID }, func(r record) string { return r.Name })
+	assert.NotNil(t, empty)
+	assert.Empty(t, empty)
+}
+
+func TestRemapMerge(t *testing.T) {
+	type transaction struct {
+		Account string
+		Amount  int
+	}
+
+	f := func(tx transaction) (string, int, error) {
+		if tx.Account == "" {
+			return "", 0, errors.New("missing account")
+		}
+		return tx.Account, tx.Amount, nil
+	}
+	sum := func(existing, incoming int) int { return existing + incoming }
+
+	t.Run("merge colliding keys", func(t *testing.T) {
+		input := []transaction{{"a", 10}, {"b", 5}, {"a", -3}, {"a", 1}}
+		got, err := slice_utils.RemapMerge(input, f, sum)
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]int{"a": 8, "b": 5}, got)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		got, err := slice_utils.RemapMerge([]transaction{{"a", 1}, {"", 2}}, f, sum)
+		assert.Error(t, err)
+		assert.Nil(t, got)
+	})
+
+	t.Run("empty slice", func(t *testing.T) {
+		got, err := slice_utils.RemapMerge([]transaction{}, f, sum)
+		assert.NoError(t, err)
+		assert.Empty(t, got)
+	})
+}
+
+func TestUnionOrdered(t *testing.T) {
+	tests := []struct {
+		name string
+		a    []string
+		b    []string
+		want []string
+	}{
+		{
+			name: "base and overrides",
+			a:    []string{"x", "y"},
+			b:    []string{"z", "x", "w"},
+			want: []string{"x", "y", "z", "w"},
+		},
+		{
+			name: "duplicates within inputs",
+			a:    []string{"x", "x"},
+			b:    []string{"y", "y"},
+			want: []string{"x", "y"},
+		},
+		{
+			name: "empty a",
+			a:    []string{},
+			b:    []string{"y"},
+			want: []string{"y"},
+		},
+		{
+			name: "both empty",
+			a:    nil,
+			b:    nil,
+			want: []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := slice_utils.UnionOrdered(tt.a, tt.b)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestChunksCopy(t *testing.T) {
+	t.Run("chunks", func(t *testing.T) {
+		got := slice_utils.ChunksCopy([]int{1, 2, 3, 4, 5}, 2)
+		assert.Equal(t, [][]int{{1, 2}, {3, 4}, {5}}, got)
+	})
+
+	t.Run("independent copies", func(t *testing.T) {
+		input := []int{1, 2, 3, 4}
+		got := slice_utils.ChunksCopy(input, 2)
+		got[0][0] = 10
+		got[1] = append(got[1], 5)
+		assert.Equal(t, []int{1, 2, 3, 4}, input)
+
+		views := slice_utils.Chunks(input, 2)
+		views[0][0] = 10
+		assert.Equal(t, []int{10, 2, 3, 4}, input)
+	})
+
+	t.Run("size zero", func(t *testing.T) {
+		input := []int{1, 2}
+		got := slice_utils.ChunksCopy(input, 0)
+		got[0][0] = 10
+		assert.Equal(t, []int{1, 2}, input)
+	})
+
+	t.Run("empty slice", func(t *testing.T) {
+		assert.Equal(t, [][]int{}, slice_utils.ChunksCopy([]int{}, 2))
+	})
+}
+
+func TestDeinterleave(t *testing.T) {
+	tests := []struct {
+		name  string
+		input []int
+		n     int
+		want  [][]int
+	}{
+		{
+			name:  "even and odd indexes",
+			input: []int{0, 1, 2, 3, 4},
+			n:     2,
+			want:  [][]int{{0, 2, 4}, {1, 3}},
+		},
+		{
+			name:  "three channels",
+			input: []int{1, 2, 3, 4, 5, 6},
+			n:     3,
+			want:  [][]int{{1, 4}, {2, 5}, {3, 6}},
+		},
+		{
+			name:  "more channels than elements",
+			input: []int{1},
+			n:     3,
+			want:  [][]int{{1}, {}, {}},
+		},
+		{
+			name:  "zero channels",
+			input: []int{1, 2},
+			n:     0,
+			want:  [][]int{{1, 2}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := slice_utils.Deinterleave(tt.input, tt.n)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestDuplicatesOrdered(t *testing.T) {
+	tests := []struct {
+		name  string
+		input []int
+		want  []int
+	}{
+		{
+			name:  "order of second appearance",
+			input: []int{5, 1, 2, 1, 5, 3, 2, 1},
+			want:  []int{1, 5, 2},
+		},
+		{
+			name:  "no duplicates",
+			input: []int{1, 2, 3},
+			want:  []int{},
+		},
+		{
+			name:  "empty slice",
+			input: []int{},
+			want:  []int{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := slice_utils.DuplicatesOrdered(tt.input)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestDeduplicateEqFunc(t *testing.T) {
+	near := func(a, b float64) bool { return math.Abs(a-b) < 0.01 }
+
+	tests := []struct {
+		name  string
+		input []float64
+		want  []float64
+	}{
+		{
+			name:  "near equal readings",
+			input: []float64{1.0, 1.005, 2.0, 0.999, 2.1},
+			want:  []float64{1.0, 2.0, 2.1},
+		},
+		{
+			name:  "no duplicates",
+			input: []float64{1, 2, 3},
+			want:  []float64{1, 2, 3},
+		},
+		{
+			name:  "empty slice",
+			input: []float64{},
+			want:  []float64{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := slice_utils.DeduplicateEqFunc(tt.input, near)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestZip3(t *testing.T) {
+	got := slice_utils.Zip3([]int{1, 2, 3}, []string{"a", "b"}, []float64{0.5, 1.5, 2.5})
+	assert.Equal(t, []slice_utils.Triple[int, string, float64]{{1, "a", 0.5}, {2, "b", 1.5}}, got)
+
+	empty := slice_utils.Zip3([]int{}, []string{"a"}, []float64{1})
+	assert.NotNil(t, empty)
+	assert.Empty(t, empty)
+}
+
+func TestCircularEqual(t *testing.T) {
+	tests := []struct {
+		name string
+		a    []int
+		b    []int
+		want bool
+	}{
+		{
+			name: "rotation",
+			a:    []int{1, 2, 3, 4},
+			b:    []int{3, 4, 1, 2},
+			want: true,
+		},
+		{
+			name: "identical",
+			a:    []int{1, 2, 3},
+			b:    []int{1, 2, 3},
+			want: true,
+		},
+		{
+			name: "reversed",
+			a:    []int{1, 2, 3},
+			b:    []int{3, 2, 1},
+			want: false,
+		},
+		{
+			name: "different length",
+			a:    []int{1, 2},
+			b:    []int{1, 2, 1},
+			want: false,
+		},
+		{
+			name: "both empty",
+			a:    []int{},
+			b:    []int{},
+			want: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, slice_utils.CircularEqual(tt.a, tt.b))
+		})
+	}
+}
+
+func TestDropLast(t *testing.T) {
+	tests := []struct {
+		name  string
+		input []int
+		n     int
+		want  []int
+	}{
+		{
+			name:  "drop trailer",
+			input: []int{1, 2, 3, 4},
+			n:     1,
+			want:  []int{1, 2, 3},
+		},
+		{
+			name:  "drop all",
+			input: []int{1, 2},
+			n:     5,
+			want:  []int{},
+		},
+		{
+			name:  "drop none",
+			input: []int{1, 2},
+			n:     0,
+			want:  []int{1, 2},
+		},
+		{
+			name:  "empty slice",
+			input: []int{},
+			n:     1,
+			want:  []int{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			input := slices.Clone(tt.input)
+			got := slice_utils.DropLast(input, tt.n)
+			assert.Equal(t, tt.want, got)
+
+			if len(got) > 0 {
+				got[0] = -1
+				assert.Equal(t, tt.input, input, "DropLast() should return a copy")
+			}
+		})
+	}
+}
+
+func TestMapInPlace(t *testing.T) {
+	input := []int{1, 2, 3}
+	got := slice_utils.MapInPlace(input, func(v int) int { return v * v })
+	assert.Equal(t, []int{1, 4, 9}, got)
+	assert.Equal(t, []int{1, 4, 9}, input, "MapInPlace() should modify the input")
+
+	allocs := testing.AllocsPerRun(10, func() {
+		slice_utils.MapInPlace(input, func(v int) int { return v })
+	})
+	assert.Zero(t, allocs)
+
+	assert.Empty(t, slice_utils.MapInPlace([]int{}, func(v int) int { return v }))
+}
+
+func TestChunkMap(t *testing.T) {
+	type summary struct {
+		Count int
+		Sum   int
+	}
+
+	f := func(chunk []int) summary {
+		return summary{len(chunk), slice_utils.SumBy(chunk, func(v int) int { return v })}
+	}
+
+	tests := []struct {
+		name  string
+		input []int
+		size  int
+		want  []summary
+	}{
+		{
+			name:  "chunks",
+			input: []int{1, 2, 3, 4, 5},
+			size:  2,
+			want:  []summary{{2, 3}, {2, 7}, {1, 5}},
+		},
+		{
+			name:  "size zero",
+			input: []int{1, 2, 3},
+			size:  0,
+			want:  []summary{{3, 6}},
+		},
+		{
+			name:  "empty slice",
+			input: []int{},
+			size:  2,
+			want:  []summary{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := slice_utils.ChunkMap(tt.input, tt.size, f)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestZipToMap(t *testing.T) {
+	tests := []struct {
+		name   string
+		keys   []string
+		values []int
+		want   map[string]int
+	}{
+		{
+			name:   "columns",
+			keys:   []string{"a", "b"},
+			values: []int{1, 2},
+			want:   map[string]int{"a": 1, "b": 2},
+		},
+		{
+			name:   "truncate",
+			keys:   []string{"a", "b", "c"},
+			values: []int{1},
+			want:   map[string]int{"a": 1},
+		},
+		{
+			name:   "last wins",
+			keys:   []string{"a", "a"},
+			values: []int{1, 2},
+			want:   map[string]int{"a": 2},
+		},
+		{
+			name:   "empty",
+			keys:   nil,
+			values: nil,
+			want:   map[string]int{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := slice_utils.ZipToMap(tt.keys, tt.values)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestEqualUnorderedFunc(t *testing.T) {
+	type item struct {
+		ID   int
+		Tags []string
+	}
+
+	id := func(v item) int { return v.ID }
+
+	tests := []struct {
+		name string
+		a    []item
+		b    []item
+		want bool
+	}{
+		{
+			name: "same keys in different order",
+			a:    []item{{ID: 1, Tags: []string{"a"}}, {ID: 2}, {ID: 3}},
+			b:    []item{{ID: 3}, {ID: 1}, {ID: 2, Tags: []string{"b"}}},
+			want: true,
+		},
+		{
+			name: "duplicates must match",
+			a:    []item{{ID: 1}, {ID: 1}, {ID: 2}},
+			b:    []item{{ID: 1}, {ID: 2}, {ID: 2}},
+			want: false,
+		},
+		{
+			name: "different keys",
+			a:    []item{{ID: 1}, {ID: 2}},
+			b:    []item{{ID: 1}, {ID: 3}},
+			want: false,
+		},
+		{
+			name: "different length",
+			a:    []item{{ID: 1}},
+			b:    []item{{ID: 1}, {ID: 1}},
+			want: false,
+		},
+		{
+			name: "both empty",
+			a:    nil,
+			b:    []item{},
+			want: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, slice_utils.EqualUnorderedFunc(tt.a, tt.b, id))
+		})
+	}
+}
@@ -7,16 +7,54 @@ package slice_utils
 
 import (
 	"cmp"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
 	"maps"
+	"math"
 	"reflect"
 	"slices"
 
 	"regexp"
 	"sort"
+
+	"golang.org/x/sync/errgroup"
+)
+
+type Integer interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr
+}
+
+type Number interface {
+	Integer | ~float32 | ~float64
+}
+
+var (
+	ErrLengthMismatch = errors.New("slices have different lengths")
+	ErrOddCount       = errors.New("odd number of values")
+	ErrOverflow       = errors.New("integer overflow")
+	ErrZeroWeight     = errors.New("total weight is zero")
 )
 
+// maxSelectHint bounds the capacity preallocated by Select and Reject, so a
+// selective filter over a large input doesn't keep a mostly unused array alive.
+const maxSelectHint = 1024
+
+// Select returns the elements for which f returns true, or nil if there are
+// none.
 func Select[Slice ~[]V, V any](slice Slice, f func(val V) bool) Slice {
-	return slices.Collect(FilterSeq(slices.Values(slice), f))
+	r := CollectN(FilterSeq(slices.Values(slice), f), selectHint(len(slice)))
+	if len(r) == 0 {
+		return nil
+	}
+
+	return r
+}
+
+func selectHint(n int) int {
+	return min(n/2, maxSelectHint)
 }
 
 func Count[Slice ~[]V, V any](slice Slice, f func(val V) bool) int {
@@ -97,12 +135,7 @@ func ToAny[Slice ~[]V, V any](slice Slice) []any {
 }
 
 func Convert[Slice ~[]V, V any, T any](slice Slice, f func(val1 V) T) []T {
-	r := slices.Collect(ConvertSeq(slices.Values(slice), f))
-	if r == nil {
-		return []T{}
-	}
-
-	return r
+	return CollectN(ConvertSeq(slices.Values(slice), f), len(slice))
 }
 
 func Aggregate[Slice ~[]V, V any, T cmp.Ordered](slice Slice, f func(val1 V) (T, error)) (T, error) {
@@ -145,24 +178,12 @@ func ToMap[Slice ~[]V, K comparable, V any](slice Slice, f func(val V) K) map[K]
 }
 
 func Duplicates[Slice ~[]V, V comparable](slice Slice) Slice {
-	tmp := map[V]int{}
-	for _, v := range slice {
-		if cnt, ok := tmp[v]; ok {
-			tmp[v] = cnt + 1
-		} else {
-			tmp[v] = 1
-		}
-	}
-
-	result := Slice{}
-
-	for k, v := range tmp {
-		if v > 1 {
-			result = append(result, k)
-		}
+	r := slices.Collect(DuplicateSeq(slices.Values(slice)))
+	if r == nil {
+		return Slice{}
 	}
 
-	return result
+	return r
 }
 
 func Deduplicate[Slice ~[]V, V comparable](s Slice) Slice {
@@ -199,6 +220,9 @@ func RemoveStrings(s []string, p *regexp.Regexp) []string {
 	return r
 }
 
+// Chunks splits the slice into chunks of size elements. The chunks are views
+// that share the backing array of the input, use ChunksCopy for independent
+// chunks.
 func Chunks[Slice ~[]V, V any](slice Slice, size int) []Slice {
 	if size < 1 {
 		if len(slice) == 0 {
@@ -244,11 +268,16 @@ func Contains[V any](slice []V, f func(val V) bool) bool {
 }
 
 func Pairs[T any](values ...T) [][2]T {
+	return PairsPadded(*new(T), values...)
+}
+
+// PairsPadded works like Pairs but uses pad as value of a dangling last key.
+func PairsPadded[T any](pad T, values ...T) [][2]T {
 	result := [][2]T{}
 
 	for i := 0; i < len(values); i += 2 {
 		key := values[i]
-		value := *new(T)
+		value := pad
 
 		if i+1 < len(values) {
 			value = values[i+1]
@@ -259,3 +288,830 @@ func Pairs[T any](values ...T) [][2]T {
 
 	return result
 }
+
+// PairsStrict works like Pairs but returns ErrOddCount instead of padding a
+// dangling last key.
+func PairsStrict[T any](values ...T) ([][2]T, error) {
+	if len(values)%2 != 0 {
+		return nil, ErrOddCount
+	}
+
+	return Pairs(values...), nil
+}
+
+// FoldRight folds the slice from the last to the first element. Unlike a left
+// fold, f receives the value first and the accumulator second.
+func FoldRight[V any, A any](slice []V, seed A, f func(val V, acc A) A) A {
+	acc := seed
+
+	for i := len(slice) - 1; i >= 0; i-- {
+		acc = f(slice[i], acc)
+	}
+
+	return acc
+}
+
+func FilterIndex[Slice ~[]V, V any](slice Slice, f func(i int, val V) bool) Slice {
+	r := slices.Collect(FilterIndexSeq(slices.Values(slice), f))
+	if r == nil {
+		return Slice{}
+	}
+
+	return r
+}
+
+// Reject returns the elements for which f returns false, or nil if there are
+// none. It is the complement of Select.
+func Reject[Slice ~[]V, V any](slice Slice, f func(val V) bool) Slice {
+	r := CollectN(RejectSeq(slices.Values(slice), f), selectHint(len(slice)))
+	if len(r) == 0 {
+		return nil
+	}
+
+	return r
+}
+
+func CountValue[V comparable](slice []V, val V) int {
+	return CountSeq(FilterSeq(slices.Values(slice), func(v V) bool {
+		return v == val
+	}))
+}
+
+func CountValues[V comparable](slice []V, vals ...V) int {
+	set := make(map[V]struct{}, len(vals))
+	for _, v := range vals {
+		set[v] = struct{}{}
+	}
+
+	return CountSeq(FilterSeq(slices.Values(slice), func(v V) bool {
+		_, ok := set[v]
+		return ok
+	}))
+}
+
+func AggregateFunc[V any, T any](slice []V, seed T, f func(acc T, val V) (T, error)) (T, error) {
+	acc := seed
+
+	for _, v := range slice {
+		var err error
+
+		acc, err = f(acc, v)
+		if err != nil {
+			return *new(T), err
+		}
+	}
+
+	return acc, nil
+}
+
+func WriteCSV[V any](w io.Writer, slice []V, header []string, row func(V) []string) error {
+	return WriteCSVSeq(w, slices.Values(slice), header, row)
+}
+
+func ToJSON[V any, T any](slice []V, f func(V) T) ([]byte, error) {
+	return json.Marshal(Convert(slice, f))
+}
+
+// Percentile returns the p-th percentile (0..100) of the slice, interpolating
+// linearly between the closest ranks of a sorted copy. Values of p outside the
+// range are clamped, a NaN p reports false.
+func Percentile[V Number](slice []V, p float64) (float64, bool) {
+	if len(slice) == 0 || math.IsNaN(p) {
+		return 0, false
+	}
+
+	sorted := slices.Clone(slice)
+	slices.Sort(sorted)
+
+	p = min(max(p, 0), 100)
+	rank := p / 100 * float64(len(sorted)-1)
+	lo := int(math.Floor(rank))
+	hi := int(math.Ceil(rank))
+
+	v := float64(sorted[lo])
+
+	return v + (float64(sorted[hi])-v)*(rank-float64(lo)), true
+}
+
+func Median[V Number](slice []V) (float64, bool) {
+	return Percentile(slice, 50)
+}
+
+// Variance returns the population variance of the slice, computed with
+// Welford's online algorithm.
+func Variance[V Number](slice []V) (float64, bool) {
+	if len(slice) == 0 {
+		return 0, false
+	}
+
+	var mean, m2 float64
+
+	for i, v := range slice {
+		x := float64(v)
+		delta := x - mean
+		mean += delta / float64(i+1)
+		m2 += delta * (x - mean)
+	}
+
+	return m2 / float64(len(slice)), true
+}
+
+// StdDev returns the population standard deviation of the slice.
+func StdDev[V Number](slice []V) (float64, bool) {
+	v, ok := Variance(slice)
+	if !ok {
+		return 0, false
+	}
+
+	return math.Sqrt(v), true
+}
+
+func Clamp[V cmp.Ordered](slice []V, lo V, hi V) []V {
+	r := slices.Collect(ClampSeq(slices.Values(slice), lo, hi))
+	if r == nil {
+		return []V{}
+	}
+
+	return r
+}
+
+// Normalize scales the elements linearly to the range [0, 1] based on the
+// minimum and maximum of the slice. If all elements are equal, every result
+// is 0.
+func Normalize[V Number](slice []V) []float64 {
+	if len(slice) == 0 {
+		return []float64{}
+	}
+
+	lo := float64(slices.Min(slice))
+	span := float64(slices.Max(slice)) - lo
+
+	return Convert(slice, func(v V) float64 {
+		if span == 0 {
+			return 0
+		}
+
+		return (float64(v) - lo) / span
+	})
+}
+
+func RunningMax[V cmp.Ordered](slice []V) []V {
+	r := slices.Collect(RunningMaxSeq(slices.Values(slice)))
+	if r == nil {
+		return []V{}
+	}
+
+	return r
+}
+
+func RunningMin[V cmp.Ordered](slice []V) []V {
+	r := slices.Collect(RunningMinSeq(slices.Values(slice)))
+	if r == nil {
+		return []V{}
+	}
+
+	return r
+}
+
+func DotProduct[V Number](a, b []V) (V, error) {
+	if len(a) != len(b) {
+		return *new(V), ErrLengthMismatch
+	}
+
+	var result V
+
+	for i := range a {
+		result += a[i] * b[i]
+	}
+
+	return result, nil
+}
+
+func AddVec[V Number](a, b []V) ([]V, error) {
+	return elementwise(a, b, func(x, y V) V { return x + y })
+}
+
+func SubVec[V Number](a, b []V) ([]V, error) {
+	return elementwise(a, b, func(x, y V) V { return x - y })
+}
+
+func MulVec[V Number](a, b []V) ([]V, error) {
+	return elementwise(a, b, func(x, y V) V { return x * y })
+}
+
+func elementwise[V Number](a, b []V, f func(x, y V) V) ([]V, error) {
+	if len(a) != len(b) {
+		return nil, ErrLengthMismatch
+	}
+
+	result := make([]V, len(a))
+	for i := range a {
+		result[i] = f(a[i], b[i])
+	}
+
+	return result, nil
+}
+
+func BinarySearch[V cmp.Ordered](slice []V, target V) (int, bool) {
+	return slices.BinarySearch(slice, target)
+}
+
+// BinarySearchFunc searches a sorted slice with f, which returns a negative
+// number if the element is before the target, zero on a match and a positive
+// number if the element is after the target.
+func BinarySearchFunc[V any](slice []V, f func(V) int) (int, bool) {
+	return slices.BinarySearchFunc(slice, struct{}{}, func(v V, _ struct{}) int {
+		return f(v)
+	})
+}
+
+func InsertSorted[V cmp.Ordered](slice []V, val V) []V {
+	return InsertSortedFunc(slice, val, cmp.Compare[V])
+}
+
+// InsertSortedFunc returns a copy of the sorted slice with val inserted after
+// all elements that compare less than or equal to it.
+func InsertSortedFunc[V any](slice []V, val V, f func(a, b V) int) []V {
+	i := sort.Search(len(slice), func(i int) bool {
+		return f(slice[i], val) > 0
+	})
+
+	result := make([]V, 0, len(slice)+1)
+	result = append(result, slice[:i]...)
+	result = append(result, val)
+
+	return append(result, slice[i:]...)
+}
+
+// ForEachParallel calls f for every element using at most workers goroutines,
+// workers <= 0 means no limit. The context passed to f is cancelled at the
+// first error, which is returned after all running calls have finished. If ctx
+// is cancelled, the remaining elements are skipped and ctx.Err() is returned.
+func ForEachParallel[V any](ctx context.Context, slice []V, workers int, f func(context.Context, V) error) error {
+	g, gctx := errgroup.WithContext(ctx)
+	if workers > 0 {
+		g.SetLimit(workers)
+	}
+
+	for _, v := range slice {
+		if gctx.Err() != nil {
+			break
+		}
+
+		g.Go(func() error {
+			return f(gctx, v)
+		})
+	}
+
+	if err := g.Wait(); err != nil {
+		return err
+	}
+
+	return ctx.Err()
+}
+
+func ContainsValue[V comparable](slice []V, val V) bool {
+	return slices.Contains(slice, val)
+}
+
+// ContainsAll reports whether every value of vals is in slice. The smaller of
+// the two is put into a set and the scan stops as soon as the answer is known.
+func ContainsAll[V comparable](slice []V, vals ...V) bool {
+	if len(vals) == 0 {
+		return true
+	}
+
+	if len(vals) > len(slice) {
+		set := setOf(slice)
+		for _, v := range vals {
+			if _, ok := set[v]; !ok {
+				return false
+			}
+		}
+
+		return true
+	}
+
+	missing := setOf(vals)
+	for _, v := range slice {
+		delete(missing, v)
+
+		if len(missing) == 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ContainsAny reports whether any value of vals is in slice. It returns at the
+// first match.
+func ContainsAny[V comparable](slice []V, vals ...V) bool {
+	if len(vals) == 0 {
+		return false
+	}
+
+	set := setOf(vals)
+
+	return slices.ContainsFunc(slice, func(v V) bool {
+		_, ok := set[v]
+		return ok
+	})
+}
+
+func setOf[V comparable](vals []V) map[V]struct{} {
+	set := make(map[V]struct{}, len(vals))
+	for _, v := range vals {
+		set[v] = struct{}{}
+	}
+
+	return set
+}
+
+func FilterMap[Slice ~[]V, V any, T any](slice Slice, f func(val V) (T, bool)) []T {
+	return CollectN(FilterMapSeq(slices.Values(slice), f), 0)
+}
+
+// Map is an alias for Convert.
+func Map[Slice ~[]V, V any, T any](slice Slice, f func(V) T) []T {
+	return Convert(slice, f)
+}
+
+// Filter is an alias for Select.
+func Filter[Slice ~[]V, V any](slice Slice, f func(V) bool) Slice {
+	return Select(slice, f)
+}
+
+func ForEachWhile[V any](slice []V, f func(val V) bool) {
+	for _, v := range slice {
+		if !f(v) {
+			return
+		}
+	}
+}
+
+// PartitionN splits the slice into n contiguous parts of nearly equal size,
+// the first parts get one extra element if the length isn't divisible by n.
+// The parts alias the input slice.
+func PartitionN[Slice ~[]V, V any](slice Slice, n int) []Slice {
+	if n <= 0 {
+		return []Slice{slice}
+	}
+
+	size := len(slice) / n
+	rest := len(slice) % n
+	result := make([]Slice, 0, n)
+
+	start := 0
+	for i := range n {
+		end := start + size
+		if i < rest {
+			end++
+		}
+
+		result = append(result, slice[start:end:end])
+		start = end
+	}
+
+	return result
+}
+
+// FlattenMap concatenates all value slices of m. The order of the groups
+// follows the map iteration and is therefore random.
+func FlattenMap[K comparable, V any](m map[K][]V) []V {
+	result := []V{}
+
+	for _, v := range m {
+		result = append(result, v...)
+	}
+
+	return result
+}
+
+// FlattenMapSorted concatenates all value slices of m in ascending key order.
+func FlattenMapSorted[K cmp.Ordered, V any](m map[K][]V) []V {
+	result := []V{}
+
+	for _, k := range slices.Sorted(maps.Keys(m)) {
+		result = append(result, m[k]...)
+	}
+
+	return result
+}
+
+type Entry[K comparable, V any] struct {
+	Key   K
+	Value V
+}
+
+// Entries returns the key/value pairs of m in random order.
+func Entries[K comparable, V any](m map[K]V) []Entry[K, V] {
+	result := make([]Entry[K, V], 0, len(m))
+
+	for k, v := range m {
+		result = append(result, Entry[K, V]{Key: k, Value: v})
+	}
+
+	return result
+}
+
+// EntriesSorted returns the key/value pairs of m in ascending key order.
+func EntriesSorted[K cmp.Ordered, V any](m map[K]V) []Entry[K, V] {
+	result := make([]Entry[K, V], 0, len(m))
+
+	for _, k := range slices.Sorted(maps.Keys(m)) {
+		result = append(result, Entry[K, V]{Key: k, Value: m[k]})
+	}
+
+	return result
+}
+
+// FromPairs builds a map from the pairs using the first element as key and the
+// second as value. The last pair wins for duplicate keys.
+func FromPairs[T comparable](pairs [][2]T) map[T]T {
+	result := make(map[T]T, len(pairs))
+
+	for _, p := range pairs {
+		result[p[0]] = p[1]
+	}
+
+	return result
+}
+
+func ZipWith[A any, B any, C any](a []A, b []B, f func(A, B) C) []C {
+	n := min(len(a), len(b))
+	result := make([]C, n)
+
+	for i := range n {
+		result[i] = f(a[i], b[i])
+	}
+
+	return result
+}
+
+func FindLast[V any](slice []V, f func(val V) bool) (V, bool) {
+	for i := len(slice) - 1; i >= 0; i-- {
+		if f(slice[i]) {
+			return slice[i], true
+		}
+	}
+
+	return *new(V), false
+}
+
+func SumBy[V any, N Number](slice []V, f func(V) N) N {
+	var result N
+
+	for _, v := range slice {
+		result += f(v)
+	}
+
+	return result
+}
+
+// AverageBy returns the mean of the values projected by f. The values are
+// accumulated as float64 to avoid integer overflow.
+func AverageBy[V any, N Number](slice []V, f func(V) N) (float64, bool) {
+	if len(slice) == 0 {
+		return 0, false
+	}
+
+	var sum float64
+
+	for _, v := range slice {
+		sum += float64(f(v))
+	}
+
+	return sum / float64(len(slice)), true
+}
+
+// MinBy returns the first element with the smallest key.
+func MinBy[V any, K cmp.Ordered](slice []V, key func(V) K) (V, bool) {
+	return extremeBy(slice, key, func(a, b K) bool { return a < b })
+}
+
+// MaxBy returns the first element with the largest key.
+func MaxBy[V any, K cmp.Ordered](slice []V, key func(V) K) (V, bool) {
+	return extremeBy(slice, key, func(a, b K) bool { return a > b })
+}
+
+func extremeBy[V any, K cmp.Ordered](slice []V, key func(V) K, better func(a, b K) bool) (V, bool) {
+	if len(slice) == 0 {
+		return *new(V), false
+	}
+
+	result := slice[0]
+	best := key(result)
+
+	for _, v := range slice[1:] {
+		if k := key(v); better(k, best) {
+			result = v
+			best = k
+		}
+	}
+
+	return result, true
+}
+
+func Intersperse[Slice ~[]V, V any](slice Slice, sep V) Slice {
+	return CollectN(IntersperseSeq(slices.Values(slice), sep), max(2*len(slice)-1, 0))
+}
+
+// ChunkReduce folds every chunk of size elements into one value, see
+// ChunkReduceSeq. The seed is reused for every chunk and must not be mutated.
+func ChunkReduce[V any, A any](slice []V, size int, seed A, f func(acc A, val V) A) []A {
+	return CollectN(ChunkReduceSeq(slices.Values(slice), size, seed, f), 0)
+}
+
+// Transpose swaps rows and columns of a row-major matrix. Ragged rows are
+// padded with zero values, so the result has as many rows as the longest
+// input row.
+func Transpose[V any](matrix [][]V) [][]V {
+	width := 0
+	for _, row := range matrix {
+		width = max(width, len(row))
+	}
+
+	result := make([][]V, width)
+	for i := range result {
+		result[i] = make([]V, len(matrix))
+	}
+
+	for j, row := range matrix {
+		for i, v := range row {
+			result[i][j] = v
+		}
+	}
+
+	return result
+}
+
+// ZipN returns one row per index with the elements of all inputs at that
+// index. The result is truncated to the shortest input.
+func ZipN[V any](inputs ...[]V) [][]V {
+	if len(inputs) == 0 {
+		return [][]V{}
+	}
+
+	n := len(inputs[0])
+	for _, s := range inputs[1:] {
+		n = min(n, len(s))
+	}
+
+	result := make([][]V, n)
+	for i := range result {
+		row := make([]V, len(inputs))
+		for j, s := range inputs {
+			row[j] = s[i]
+		}
+
+		result[i] = row
+	}
+
+	return result
+}
+
+func ConvertIndex[Slice ~[]V, V any, T any](slice Slice, f func(i int, val V) T) []T {
+	return CollectN(ConvertIndexSeq(slices.Values(slice), f), len(slice))
+}
+
+// WeightedAverage returns sum(value*weight)/sum(weight). It returns
+// ErrLengthMismatch if the slices differ in length and ErrZeroWeight instead
+// of NaN if the weights sum up to zero, which includes empty input.
+func WeightedAverage[V Number, W Number](values []V, weights []W) (float64, error) {
+	if len(values) != len(weights) {
+		return 0, ErrLengthMismatch
+	}
+
+	var sum, total float64
+
+	for i, v := range values {
+		w := float64(weights[i])
+		sum += float64(v) * w
+		total += w
+	}
+
+	if total == 0 {
+		return 0, ErrZeroWeight
+	}
+
+	return sum / total, nil
+}
+
+// Histogram counts the values per bin defined by the ascending edges. The
+// result has len(edges)+1 bins: bin 0 counts values below the first edge, bin
+// i counts values in [edges[i-1], edges[i]) and the last bin counts values
+// greater than or equal to the last edge. NaN values are not counted.
+func Histogram[V Number](slice []V, edges []V) []int {
+	result := make([]int, len(edges)+1)
+
+	for _, v := range slice {
+		if v != v {
+			continue
+		}
+
+		i := sort.Search(len(edges), func(i int) bool {
+			return edges[i] > v
+		})
+
+		result[i]++
+	}
+
+	return result
+}
+
+func CumSum[V Number](slice []V) []V {
+	return CollectN(CumSumSeq(slices.Values(slice)), len(slice))
+}
+
+func SelectN[Slice ~[]V, V any](slice Slice, n int, f func(V) bool) Slice {
+	return CollectN(FilterTakeSeq(slices.Values(slice), n, f), min(max(n, 0), len(slice)))
+}
+
+// ToStrings renders every element as string. Unlike To[string] it uses the
+// string value, the String method of a fmt.Stringer or the %v format.
+func ToStrings[Slice ~[]V, V any](slice Slice) []string {
+	return CollectN(StringsSeq(slices.Values(slice)), len(slice))
+}
+
+func ToMapValues[V any, K comparable, T any](slice []V, keyFn func(V) K, valFn func(V) T) map[K]T {
+	result := make(map[K]T, len(slice))
+
+	for _, v := range slice {
+		result[keyFn(v)] = valFn(v)
+	}
+
+	return result
+}
+
+// RemapMerge works like Remap but combines the values of colliding keys with
+// merge instead of keeping the last one.
+func RemapMerge[V any, K comparable, T any](slice []V, f func(V) (K, T, error), merge func(existing, incoming T) T) (map[K]T, error) {
+	result := map[K]T{}
+
+	for _, v := range slice {
+		k, t, err := f(v)
+		if err != nil {
+			return nil, err
+		}
+
+		if existing, ok := result[k]; ok {
+			result[k] = merge(existing, t)
+		} else {
+			result[k] = t
+		}
+	}
+
+	return result, nil
+}
+
+// UnionOrdered returns the distinct elements of a followed by those of b in
+// order of their first appearance.
+func UnionOrdered[V comparable](a, b []V) []V {
+	return CollectN(DeduplicationSeq(slices.Values(slices.Concat(a, b))), 0)
+}
+
+// ChunksCopy works like Chunks but returns independent copies of the chunks.
+func ChunksCopy[Slice ~[]V, V any](slice Slice, size int) []Slice {
+	result := Chunks(slice, size)
+	for i, c := range result {
+		result[i] = slices.Clone(c)
+	}
+
+	return result
+}
+
+// Deinterleave distributes the elements round-robin into n slices, element i
+// goes to slice i%n. For n <= 0 the result holds a copy of the whole slice.
+func Deinterleave[V any](slice []V, n int) [][]V {
+	if n <= 0 {
+		return [][]V{slices.Clone(slice)}
+	}
+
+	result := make([][]V, n)
+	for i := range result {
+		result[i] = make([]V, 0, (len(slice)+n-1-i)/n)
+	}
+
+	for i, v := range slice {
+		result[i%n] = append(result[i%n], v)
+	}
+
+	return result
+}
+
+// DuplicatesOrdered is an alias for Duplicates, which returns the duplicated
+// values in the order in which they were first seen a second time.
+func DuplicatesOrdered[Slice ~[]V, V comparable](slice Slice) Slice {
+	return Duplicates(slice)
+}
+
+// DeduplicateEqFunc keeps every element that isn't equal, according to eq, to
+// an already kept one. It compares each element with all kept elements and is
+// therefore O(n^2) in the worst case.
+func DeduplicateEqFunc[Slice ~[]V, V any](slice Slice, eq func(a, b V) bool) Slice {
+	result := Slice{}
+
+	for _, v := range slice {
+		if !slices.ContainsFunc(result, func(kept V) bool { return eq(kept, v) }) {
+			result = append(result, v)
+		}
+	}
+
+	return result
+}
+
+type Triple[A, B, C any] struct {
+	First  A
+	Second B
+	Third  C
+}
+
+// Zip3 combines the elements of three slices by index. The result is
+// truncated to the shortest input.
+func Zip3[A, B, C any](a []A, b []B, c []C) []Triple[A, B, C] {
+	n := min(len(a), len(b), len(c))
+	result := make([]Triple[A, B, C], n)
+
+	for i := range n {
+		result[i] = Triple[A, B, C]{First: a[i], Second: b[i], Third: c[i]}
+	}
+
+	return result
+}
+
+// CircularEqual reports whether b is a rotation of a, i.e. whether b occurs
+// in a concatenated with itself.
+func CircularEqual[V comparable](a, b []V) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	if len(a) == 0 {
+		return true
+	}
+
+	doubled := slices.Concat(a, a)
+	for i := range a {
+		if slices.Equal(doubled[i:i+len(b)], b) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func DropLast[Slice ~[]V, V any](slice Slice, n int) Slice {
+	n = min(max(n, 0), len(slice))
+
+	return append(Slice{}, slice[:len(slice)-n]...)
+}
+
+// MapInPlace replaces every element with the result of f and returns the same
+// slice. Unlike Change it modifies the input instead of allocating a new slice.
+func MapInPlace[Slice ~[]V, V any](slice Slice, f func(V) V) Slice {
+	for i, v := range slice {
+		slice[i] = f(v)
+	}
+
+	return slice
+}
+
+// ChunkMap applies f to every chunk of size elements, see Chunks. The chunks
+// passed to f share the backing array of the input.
+func ChunkMap[V any, T any](slice []V, size int, f func(chunk []V) T) []T {
+	return Convert(Chunks(slice, size), f)
+}
+
+// ZipToMap pairs keys and values by index. The result is truncated to the
+// shorter input and the last value wins for duplicate keys.
+func ZipToMap[K comparable, V any](keys []K, values []V) map[K]V {
+	n := min(len(keys), len(values))
+	result := make(map[K]V, n)
+
+	for i := range n {
+		result[keys[i]] = values[i]
+	}
+
+	return result
+}
+
+// EqualUnorderedFunc reports whether a and b contain the same elements in any
+// order, comparing the keys returned by key including their multiplicity.
+func EqualUnorderedFunc[V any, K comparable](a, b []V, key func(V) K) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	countA := make(map[K]int, len(a))
+	for _, v := range a {
+		countA[key(v)]++
+	}
+
+	countB := make(map[K]int, len(b))
+	for _, v := range b {
+		countB[key(v)]++
+	}
+
+	return maps.Equal(countA, countB)
+}